@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/thusai/spiral/config"
 	"github.com/thusai/spiral/core"
@@ -13,12 +16,12 @@ import (
 func AddCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "add",
-		Usage: "Add milestones, tasks, or subtasks",
+		Usage: "Add milestones, tasks, or subtasks at any depth",
 		Subcommands: []*cli.Command{
 			{
 				Name:  "milestone",
 				Usage: "Add a new milestone",
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					&cli.StringFlag{
 						Name:  "id", 
 						Usage: "Milestone ID (family-prefixed, e.g., D3) - auto-generated if not provided",
@@ -30,30 +33,27 @@ func AddCommand() *cli.Command {
 					},
 					&cli.StringFlag{
 						Name:  "family", 
-						Usage: "Product family (e.g., D, E, F)",
-						Value: "D",
+						Usage: "Registered product family (defaults to the current context's family)",
 					},
 					&cli.StringFlag{
 						Name:  "priority", 
-						Usage: "Priority: low, medium, high, critical",
-						Value: "medium",
+						Usage: "Priority: low, medium, high, critical (defaults to the family's default priority)",
 					},
 					&cli.StringFlag{
 						Name:  "cycle-status", 
-						Usage: "Cycle status: planned, in-cycle",
-						Value: "planned",
+						Usage: "Cycle status (defaults to the first state of the cycle_status workflow)",
 					},
 					&cli.StringFlag{
 						Name:  "notes", 
 						Usage: "Optional notes",
 					},
-				},
+				}, planningFlags()...),
 				Action: addMilestone,
 			},
 			{
 				Name:  "task",
 				Usage: "Add a new task to a milestone",
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					&cli.StringFlag{
 						Name:  "id", 
 						Usage: "Task ID (auto-generated if not provided)",
@@ -70,8 +70,7 @@ func AddCommand() *cli.Command {
 					},
 					&cli.StringFlag{
 						Name:  "status", 
-						Value: "planned", 
-						Usage: "Task status: planned, in-progress, done, blocked",
+						Usage: "Task status (defaults to the first state of the status workflow)",
 					},
 					&cli.StringFlag{
 						Name:  "priority", 
@@ -81,13 +80,13 @@ func AddCommand() *cli.Command {
 						Name:  "notes", 
 						Usage: "Optional notes",
 					},
-				},
+				}, planningFlags()...),
 				Action: addTask,
 			},
 			{
 				Name:  "subtask",
-				Usage: "Add a new subtask to a task",
-				Flags: []cli.Flag{
+				Usage: "Add a new subtask below a task or another subtask",
+				Flags: append([]cli.Flag{
 					&cli.StringFlag{
 						Name:  "id", 
 						Usage: "Subtask ID (auto-generated if not provided)",
@@ -95,7 +94,7 @@ func AddCommand() *cli.Command {
 					&cli.StringFlag{
 						Name:     "parent", 
 						Required: true, 
-						Usage:    "Parent task ID (e.g., D3.1)",
+						Usage:    "Parent task or subtask ID (e.g., D3.1, D3.1.2)",
 					},
 					&cli.StringFlag{
 						Name:     "title", 
@@ -104,8 +103,7 @@ func AddCommand() *cli.Command {
 					},
 					&cli.StringFlag{
 						Name:  "status", 
-						Value: "planned", 
-						Usage: "Subtask status: planned, in-progress, done, blocked",
+						Usage: "Subtask status (defaults to the first state of the status workflow)",
 					},
 					&cli.StringFlag{
 						Name:  "priority", 
@@ -115,7 +113,7 @@ func AddCommand() *cli.Command {
 						Name:  "notes", 
 						Usage: "Optional notes",
 					},
-				},
+				}, planningFlags()...),
 				Action: addTask, // Subtasks are just tasks with task parents
 			},
 		},
@@ -123,95 +121,148 @@ func AddCommand() *cli.Command {
 }
 
 func addMilestone(c *cli.Context) error {
-	// Load roadmap
-	roadmap, err := core.LoadRoadmapFromFile("spiral.yml")
+	// Build the milestone under the roadmap lock, so a generated ID can't be
+	// taken by another command in the meantime
+	var milestone types.Milestone
+	_, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		var err error
+		milestone, err = newMilestone(c, roadmap)
+		if err != nil {
+			return err
+		}
+		return core.AddMilestone(roadmap, milestone)
+	})
 	if err != nil {
 		return err
 	}
 
+	fmt.Printf("✅ Added milestone %s: %s\n", milestone.ID, milestone.Title)
+	fmt.Printf("   Family: %s, Priority: %s, Cycle: %s\n", 
+		milestone.Family, milestone.Priority, milestone.CycleStatus)
+
+	// Set as current context if in-cycle
+	if types.WorkflowFor(types.WorkflowCycleStatus).IsWorking(milestone.CycleStatus) {
+		ctx := types.Context{
+			MilestoneID: milestone.ID,
+			Family:      milestone.Family,
+		}
+		if err := config.SaveContext(ctx); err == nil {
+			fmt.Printf("🎯 Set %s as current working context\n", milestone.ID)
+		}
+	}
+
+	return nil
+}
+
+// newMilestone builds the milestone described by the add flags, generating
+// its ID from the roadmap when none is given
+func newMilestone(c *cli.Context, roadmap *types.Roadmap) (types.Milestone, error) {
 	// Get or generate milestone ID
-	milestoneID := c.String("id")
+	milestoneID := types.NormalizeID(c.String("id"))
 	family := c.String("family")
+	generator := core.NewIDGenerator(roadmap)
+
+	if family == "" {
+		ctx, _ := config.LoadContext()
+		family = generator.GetFamilyFromContext(&ctx)
+	}
 	
 	if milestoneID == "" {
 		// Auto-generate ID using family
-		generator := core.NewIDGenerator(roadmap)
 		milestoneID = generator.GenerateNextMilestoneID(family)
 	} else {
 		// Validate provided ID format
 		parsedID, err := types.ParseID(milestoneID)
 		if err != nil {
-			return fmt.Errorf("invalid ID format: %w", err)
+			return types.Milestone{}, fmt.Errorf("invalid ID format: %w", err)
 		}
 		if parsedID.Level() != 0 {
-			return fmt.Errorf("expected milestone ID, got: %s", milestoneID)
+			return types.Milestone{}, fmt.Errorf("expected milestone ID, got: %s", milestoneID)
 		}
 		// Extract family from provided ID
 		family = parsedID.Family
 	}
 
+	registered := roadmap.GetFamily(family)
+	if registered == nil {
+		return types.Milestone{}, fmt.Errorf("family %s is not registered (see 'spiral family list', or add it with 'spiral family add %s --name=...')",
+			family, family)
+	}
+
 	// Validate input
 	priority := c.String("priority")
-	if priority != "" && !types.IsValidPriority(priority) {
-		return fmt.Errorf("invalid priority: %s", priority)
+	if priority == "" {
+		priority = registered.DefaultPriority
+	}
+	if priority == "" {
+		priority = "medium"
+	}
+	if err := core.CheckField(types.SchemaMilestones, "priority", priority); err != nil {
+		return types.Milestone{}, err
 	}
 
 	cycleStatus := c.String("cycle-status")
-	if cycleStatus != "" && !types.IsValidCycleStatus(cycleStatus) {
-		return fmt.Errorf("invalid cycle status: %s", cycleStatus)
+	if cycleStatus == "" {
+		cycleStatus = types.WorkflowFor(types.WorkflowCycleStatus).Initial()
+	}
+	if err := core.CheckField(types.SchemaMilestones, "cycle_status", cycleStatus); err != nil {
+		return types.Milestone{}, err
 	}
 
 	// Check for duplicate ID
-	generator := core.NewIDGenerator(roadmap)
 	if err := generator.ValidateID(milestoneID); err != nil {
-		return err
+		return types.Milestone{}, err
 	}
 
-	// Create milestone
 	milestone := types.Milestone{
 		ID:          milestoneID,
 		Family:      family,
 		Title:       c.String("title"),
 		Priority:    priority,
 		CycleStatus: cycleStatus,
-		Status:      "planned", // Default status
+		Status:      types.WorkflowFor(types.WorkflowStatus).Initial(),
 		Notes:       c.String("notes"),
 	}
-
-	// Add milestone to roadmap
-	roadmap.Milestones = append(roadmap.Milestones, milestone)
-
-	// Save roadmap
-	if err := core.SaveRoadmapToFile(roadmap, "spiral.yml"); err != nil {
-		return fmt.Errorf("failed to save roadmap: %w", err)
-	}
-
-	fmt.Printf("✅ Added milestone %s: %s\n", milestone.ID, milestone.Title)
-	fmt.Printf("   Family: %s, Priority: %s, Cycle: %s\n", 
-		milestone.Family, milestone.Priority, milestone.CycleStatus)
-
-	// Set as current context if in-cycle
-	if milestone.CycleStatus == "in-cycle" {
-		ctx := types.Context{
-			MilestoneID: milestone.ID,
-			Family:      milestone.Family,
-		}
-		if err := config.SaveContext(ctx); err == nil {
-			fmt.Printf("🎯 Set %s as current working context\n", milestone.ID)
-		}
+	if err := applyPlanningFlags(c, &milestone.Details, &milestone.Metadata); err != nil {
+		return types.Milestone{}, err
 	}
-
-	return nil
+	return milestone, nil
 }
 
 func addTask(c *cli.Context) error {
-	// Load roadmap
-	roadmap, err := core.LoadRoadmapFromFile("spiral.yml")
+	// Build the task under the roadmap lock, so a generated ID can't be
+	// taken by another command in the meantime
+	var task types.Task
+	var isParentTask bool
+	_, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		var err error
+		task, isParentTask, err = newTask(c, roadmap)
+		if err != nil {
+			return err
+		}
+		return core.AddTask(roadmap, task)
+	})
 	if err != nil {
 		return err
 	}
 
-	parentID := c.String("parent")
+	// Determine display type
+	taskType := "task"
+	if isParentTask {
+		taskType = "subtask"
+	}
+
+	fmt.Printf("✅ Added %s %s: %s\n", taskType, task.ID, task.Title)
+	fmt.Printf("   Parent: %s, Status: %s\n", task.ParentID, task.Status)
+
+	return nil
+}
+
+// newTask builds the task or subtask described by the add flags, generating
+// its ID from the roadmap when none is given. It also reports whether the
+// parent is a task.
+func newTask(c *cli.Context, roadmap *types.Roadmap) (types.Task, bool, error) {
+	parentID := types.NormalizeID(c.String("parent"))
 	
 	// Validate parent exists
 	parentExists := false
@@ -237,54 +288,57 @@ func addTask(c *cli.Context) error {
 	}
 	
 	if !parentExists {
-		return fmt.Errorf("parent %s not found", parentID)
+		return types.Task{}, false, fmt.Errorf("parent %s not found", parentID)
 	}
 
 	// Get or generate task ID
-	taskID := c.String("id")
+	taskID := types.NormalizeID(c.String("id"))
 	generator := core.NewIDGenerator(roadmap)
 	
 	if taskID == "" {
 		// Auto-generate ID based on parent
+		var err error
 		if isParentTask {
 			taskID, err = generator.GenerateNextSubtaskID(parentID)
 		} else {
 			taskID, err = generator.GenerateNextTaskID(parentID)
 		}
 		if err != nil {
-			return fmt.Errorf("failed to generate ID: %w", err)
+			return types.Task{}, false, fmt.Errorf("failed to generate ID: %w", err)
 		}
 	} else {
 		// Validate provided ID
 		if err := generator.ValidateID(taskID); err != nil {
-			return err
+			return types.Task{}, false, err
 		}
 		
 		// Verify ID matches parent structure
 		parsedID, err := types.ParseID(taskID)
 		if err != nil {
-			return fmt.Errorf("invalid ID format: %w", err)
+			return types.Task{}, false, fmt.Errorf("invalid ID format: %w", err)
 		}
 		
 		expectedParent := parsedID.ParentID()
 		if expectedParent != parentID {
-			return fmt.Errorf("ID %s does not match parent %s (expected parent: %s)", 
+			return types.Task{}, false, fmt.Errorf("ID %s does not match parent %s (expected parent: %s)", 
 				taskID, parentID, expectedParent)
 		}
 	}
 
 	// Validate input
 	status := c.String("status")
-	if status != "" && !types.IsValidTaskStatus(status) {
-		return fmt.Errorf("invalid status: %s", status)
+	if status == "" {
+		status = types.WorkflowFor(types.WorkflowStatus).Initial()
+	}
+	if err := core.CheckField(types.SchemaTasks, "status", status); err != nil {
+		return types.Task{}, false, err
 	}
 
 	priority := c.String("priority")
-	if priority != "" && !types.IsValidPriority(priority) {
-		return fmt.Errorf("invalid priority: %s", priority)
+	if err := core.CheckField(types.SchemaTasks, "priority", priority); err != nil {
+		return types.Task{}, false, err
 	}
 
-	// Create task
 	task := types.Task{
 		ID:       taskID,
 		ParentID: parentID,
@@ -293,23 +347,161 @@ func addTask(c *cli.Context) error {
 		Priority: priority,
 		Notes:    c.String("notes"),
 	}
+	if err := applyPlanningFlags(c, &task.Details, &task.Metadata); err != nil {
+		return types.Task{}, false, err
+	}
+	return task, isParentTask, nil
+}
 
-	// Add task to roadmap
-	roadmap.Tasks = append(roadmap.Tasks, task)
+// planningFlags are the add flags shared by milestones, tasks and subtasks
+func planningFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "size",
+			Usage: "Size estimate: xs, s, m, l, xl (or the project's size scale)",
+		},
+		&cli.Float64Flag{
+			Name:  "points",
+			Usage: "Point estimate (overrides size)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "assignee",
+			Usage: "Person working on it (repeatable; handle, name or email from the roster)",
+		},
+		&cli.StringFlag{
+			Name:  "start",
+			Usage: "Start date (YYYY-MM-DD or e.g. \"next monday\", \"in 2 weeks\")",
+		},
+		&cli.StringFlag{
+			Name:  "due",
+			Usage: "Hard deadline (YYYY-MM-DD or e.g. \"next friday\", \"+3d\")",
+		},
+		&cli.StringFlag{
+			Name:  "target",
+			Usage: "Soft target date (YYYY-MM-DD or a relative expression)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "label",
+			Usage: "Label to attach (repeatable)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "field",
+			Usage: "Custom field as key=value (repeatable)",
+		},
+	}
+}
 
-	// Save roadmap
-	if err := core.SaveRoadmapToFile(roadmap, "spiral.yml"); err != nil {
-		return fmt.Errorf("failed to save roadmap: %w", err)
+// applyPlanningFlags sets an item's planning fields and custom fields from
+// the planningFlags
+func applyPlanningFlags(c *cli.Context, details *types.Details, metadata *map[string]string) error {
+	parsed, err := detailsFromFlags(c)
+	if err != nil {
+		return err
+	}
+	fields, err := metadataFromFlags(c)
+	if err != nil {
+		return err
 	}
+	*details, *metadata = parsed, fields
+	return nil
+}
 
-	// Determine display type
-	taskType := "task"
-	if isParentTask {
-		taskType = "subtask"
+// detailsFromFlags builds the shared planning fields from the add flags
+func detailsFromFlags(c *cli.Context) (types.Details, error) {
+	assignees, err := resolveAssignees(c.StringSlice("assignee"))
+	if err != nil {
+		return types.Details{}, err
 	}
 
-	fmt.Printf("✅ Added %s %s: %s\n", taskType, task.ID, task.Title)
-	fmt.Printf("   Parent: %s, Status: %s\n", task.ParentID, task.Status)
+	details := types.Details{
+		Size:      c.String("size"),
+		Points:    c.Float64("points"),
+		Assignees: assignees,
+		Labels:    c.StringSlice("label"),
+	}
 
-	return nil
-} 
\ No newline at end of file
+	if details.Size != "" && !types.IsValidSize(details.Size) {
+		return details, fmt.Errorf("invalid size: %s (allowed: %s)", details.Size, strings.Join(sizeScale(), ", "))
+	}
+	if details.Points < 0 {
+		return details, fmt.Errorf("points cannot be negative: %v", details.Points)
+	}
+	for _, label := range details.Labels {
+		if !types.IsValidLabel(label) {
+			return details, fmt.Errorf("invalid label: %q (use letters, digits and - _ . : /)", label)
+		}
+	}
+
+	now := time.Now()
+	for _, date := range []struct {
+		flag string
+		dest *types.Date
+	}{
+		{"start", &details.Start},
+		{"due", &details.Due},
+		{"target", &details.Target},
+	} {
+		parsed, err := types.ParseDate(c.String(date.flag), now)
+		if err != nil {
+			return details, fmt.Errorf("invalid --%s: %w", date.flag, err)
+		}
+		*date.dest = parsed
+	}
+
+	return details, nil
+}
+
+// metadataFromFlags collects --field key=value pairs. Keys with a custom
+// field definition are parsed by type; other keys are kept as typed.
+func metadataFromFlags(c *cli.Context) (map[string]string, error) {
+	assignments := c.StringSlice("field")
+	if len(assignments) == 0 {
+		return nil, nil
+	}
+
+	metadata := make(map[string]string, len(assignments))
+	now := time.Now()
+	for _, assignment := range assignments {
+		key, value, err := types.ParseFieldAssignment(assignment)
+		if err != nil {
+			return nil, err
+		}
+		if field := types.FindCustomField(key); field != nil {
+			if value, err = field.Parse(value, now); err != nil {
+				return nil, err
+			}
+		}
+		metadata[key] = value
+	}
+	return metadata, nil
+}
+
+// sizeScale lists the configured sizes from smallest to largest
+func sizeScale() []string {
+	sizes := make([]string, 0, len(types.SizePoints))
+	for size := range types.SizePoints {
+		sizes = append(sizes, size)
+	}
+	sort.Slice(sizes, func(i, j int) bool {
+		return types.SizePoints[sizes[i]] < types.SizePoints[sizes[j]]
+	})
+	return sizes
+}
+
+// resolveAssignees maps assignees to roster handles. Without a roster any
+// name is accepted as given.
+func resolveAssignees(assignees []string) ([]string, error) {
+	if len(projectConfig.People) == 0 {
+		return assignees, nil
+	}
+
+	resolved := make([]string, 0, len(assignees))
+	for _, assignee := range assignees {
+		person := projectConfig.FindPerson(assignee)
+		if person == nil {
+			return nil, fmt.Errorf("unknown assignee: %s (add them to people in .spiral/config.json)", assignee)
+		}
+		resolved = append(resolved, person.Handle)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/thusai/spiral/core"
+	"github.com/thusai/spiral/types"
+	"github.com/urfave/cli/v2"
+)
+
+// CommentCommand returns the comment subcommand
+func CommentCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "comment",
+		Usage:     "Add a comment to an item, or list its comments",
+		ArgsUsage: "<id> [\"<comment>\"]",
+		Action:    comment,
+	}
+}
+
+func comment(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return fmt.Errorf("usage: spiral comment <id> [\"<comment>\"]")
+	}
+	id := types.NormalizeID(c.Args().First())
+	body := strings.Join(c.Args().Slice()[1:], " ")
+
+	// Without a comment just show the discussion so far
+	if body == "" {
+		roadmap, err := roadmapStore.Load()
+		if err != nil {
+			return err
+		}
+		details := roadmap.DetailsOf(id)
+		if details == nil {
+			return fmt.Errorf("item %s not found", id)
+		}
+		if len(details.Comments) == 0 {
+			fmt.Printf("No comments on %s yet\n", id)
+			return nil
+		}
+		fmt.Printf("💬 %s - %s:\n", color.CyanString(id), roadmap.TitleOf(id))
+		printComments(details.Comments)
+		return nil
+	}
+
+	_, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		_, err := core.AddComment(roadmap, id, body)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("💬 Commented on %s\n", color.CyanString(id))
+	return nil
+}
+
+// printComments lists comments oldest first with their author and time
+func printComments(comments []types.Comment) {
+	for _, comment := range comments {
+		author := comment.Author
+		if author == "" {
+			author = "unknown"
+		}
+		fmt.Printf("   %s %s\n",
+			color.BlueString(author),
+			color.HiBlackString(comment.At.Local().Format("2006-01-02 15:04")))
+		for _, line := range strings.Split(comment.Body, "\n") {
+			fmt.Printf("     %s\n", line)
+		}
+	}
+}
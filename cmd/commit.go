@@ -37,8 +37,9 @@ func handleSmartCommit(message string, autoMode bool) error {
 		return fmt.Errorf("not in a git repository")
 	}
 
-	// Load current roadmap and context
-	roadmap, err := core.LoadRoadmap()
+	// Load current roadmap and context. Commands that change the roadmap
+	// read it again under its lock.
+	roadmap, err := roadmapStore.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load roadmap: %w", err)
 	}
@@ -75,7 +76,7 @@ func commitWithContext(roadmap *types.Roadmap, context types.Context, message st
 	fmt.Printf("🎯 Current context: %s (%s)\n", currentMilestone.ID, currentMilestone.Title)
 
 	if autoMode {
-		return autoCommitWithContext(roadmap, currentMilestone, message)
+		return autoCommitWithContext(currentMilestone, message)
 	} else {
 		return interactiveCommitWithContext(roadmap, currentMilestone, message)
 	}
@@ -83,48 +84,17 @@ func commitWithContext(roadmap *types.Roadmap, context types.Context, message st
 
 func commitWithoutContext(roadmap *types.Roadmap, message string, autoMode bool) error {
 	if autoMode {
-		return autoCommitWithoutContext(roadmap, message)
+		return autoCommitWithoutContext(message)
 	} else {
 		return interactiveCommitWithoutContext(roadmap, message)
 	}
 }
 
-func autoCommitWithContext(roadmap *types.Roadmap, milestone *types.Milestone, message string) error {
+func autoCommitWithContext(milestone *types.Milestone, message string) error {
 	fmt.Printf("🔍 Auto-detected context: %s (%s)\n", milestone.ID, milestone.Title)
 	fmt.Printf("Creating task automatically...\n")
 
-	// Generate next task ID
-	nextTaskID, err := core.GenerateNextID(milestone.ID, roadmap)
-	if err != nil {
-		return fmt.Errorf("failed to generate task ID: %w", err)
-	}
-
-	// Create new task
-	newTask := types.Task{
-		ID:       nextTaskID,
-		ParentID: milestone.ID,
-		Title:    message,
-		Status:   "done", // Mark as done since we're committing completed work
-	}
-
-	// Add task to roadmap
-	roadmap.Tasks = append(roadmap.Tasks, newTask)
-
-	// Save roadmap
-	if err := core.SaveRoadmap(roadmap); err != nil {
-		return fmt.Errorf("failed to save roadmap: %w", err)
-	}
-
-	// Create git commit with tag
-	commitMessage := fmt.Sprintf("[%s] %s", nextTaskID, message)
-	if err := gitCommit(commitMessage); err != nil {
-		return fmt.Errorf("git commit failed: %w", err)
-	}
-
-	fmt.Printf("✅ Created commit: %s\n", commitMessage)
-	fmt.Printf("✅ Added task: %s\n", nextTaskID)
-
-	return nil
+	return commitTask(milestone.ID, message)
 }
 
 func interactiveCommitWithContext(roadmap *types.Roadmap, milestone *types.Milestone, message string) error {
@@ -138,106 +108,110 @@ func interactiveCommitWithContext(roadmap *types.Roadmap, milestone *types.Miles
 		return handleContextSwitch(roadmap, message)
 	}
 
-	// Generate next task ID
-	nextTaskID, err := core.GenerateNextID(milestone.ID, roadmap)
-	if err != nil {
-		return fmt.Errorf("failed to generate task ID: %w", err)
-	}
-
-	// Create new task
-	newTask := types.Task{
-		ID:       nextTaskID,
-		ParentID: milestone.ID,
-		Title:    message,
-		Status:   "done",
-	}
+	return commitTask(milestone.ID, message)
+}
 
-	// Add task to roadmap
-	roadmap.Tasks = append(roadmap.Tasks, newTask)
+// commitTask adds a done task for the work being committed under a
+// milestone, then makes the git commit tagged with its ID
+func commitTask(milestoneID, message string) error {
+	// Generate the ID and add the task under the roadmap lock, so a task
+	// added in another terminal meanwhile can't be overwritten or share it
+	var nextTaskID string
+	_, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		var err error
+		nextTaskID, err = core.GenerateNextID(milestoneID, roadmap)
+		if err != nil {
+			return fmt.Errorf("failed to generate task ID: %w", err)
+		}
 
-	// Save roadmap
-	if err := core.SaveRoadmap(roadmap); err != nil {
-		return fmt.Errorf("failed to save roadmap: %w", err)
+		// Mark as done since we're committing completed work
+		return core.AddTask(roadmap, types.Task{
+			ID:       nextTaskID,
+			ParentID: milestoneID,
+			Title:    message,
+			Status:   types.WorkflowFor(types.WorkflowStatus).Completed(),
+		})
+	})
+	if err != nil {
+		return err
 	}
 
 	// Create git commit with tag
-	commitMessage := fmt.Sprintf("[%s] %s", nextTaskID, message)
+	commitMessage := core.FormatIDForCommit(nextTaskID) + " " + message
 	if err := gitCommit(commitMessage); err != nil {
 		return fmt.Errorf("git commit failed: %w", err)
 	}
 
 	fmt.Printf("✅ Created commit: %s\n", commitMessage)
+	recordCommit(commitMessage)
 	fmt.Printf("✅ Added task: %s\n", nextTaskID)
 
 	return nil
 }
 
-func autoCommitWithoutContext(roadmap *types.Roadmap, message string) error {
+func autoCommitWithoutContext(message string) error {
 	fmt.Printf("🎯 No working context - auto-creating milestone from message\n")
 
 	// Extract title from message (first part before dash if present)
 	title := extractMilestoneTitle(message)
-	
-	// Generate next milestone ID using default family 'S' for now
-	nextMilestoneID, err := core.GenerateNextFamilyID("S", roadmap)
-	if err != nil {
-		return fmt.Errorf("failed to generate milestone ID: %w", err)
-	}
 
-	fmt.Printf("🎯 Auto-creating milestone: %s - %s\n", nextMilestoneID, title)
+	// Create the milestone and its first task under the roadmap lock
+	var family, nextMilestoneID, firstTaskID string
+	_, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		// Generate next milestone ID in the auto-commit family
+		family = autoCommitFamily(roadmap)
+		var err error
+		nextMilestoneID, err = core.GenerateNextFamilyID(family, roadmap)
+		if err != nil {
+			return fmt.Errorf("failed to generate milestone ID: %w", err)
+		}
 
-	// Create new milestone
-	newMilestone := types.Milestone{
-		ID:          nextMilestoneID,
-		Family:      "S",
-		Title:       title,
-		Priority:    "medium",
-		CycleStatus: "in-cycle",
-		Status:      "in-progress",
-	}
+		err = core.AddMilestone(roadmap, types.Milestone{
+			ID:          nextMilestoneID,
+			Family:      family,
+			Title:       title,
+			Priority:    "medium",
+			CycleStatus: types.WorkflowFor(types.WorkflowCycleStatus).Working(),
+			Status:      types.WorkflowFor(types.WorkflowStatus).Working(),
+		})
+		if err != nil {
+			return err
+		}
 
-	// Add milestone to roadmap
-	roadmap.Milestones = append(roadmap.Milestones, newMilestone)
+		firstTaskID, err = core.GenerateNextID(nextMilestoneID, roadmap)
+		if err != nil {
+			return fmt.Errorf("failed to generate task ID: %w", err)
+		}
+		return core.AddTask(roadmap, types.Task{
+			ID:       firstTaskID,
+			ParentID: nextMilestoneID,
+			Title:    message,
+			Status:   types.WorkflowFor(types.WorkflowStatus).Completed(),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🎯 Auto-creating milestone: %s - %s\n", nextMilestoneID, title)
 
 	// Set as current context
 	context := types.Context{
 		MilestoneID: nextMilestoneID,
-		Family:      "S",
+		Family:      family,
 	}
 	if err := config.SaveContext(context); err != nil {
 		return fmt.Errorf("failed to save context: %w", err)
 	}
 
-	// Generate first task ID
-	firstTaskID, err := core.GenerateNextID(nextMilestoneID, roadmap)
-	if err != nil {
-		return fmt.Errorf("failed to generate task ID: %w", err)
-	}
-
-	// Create new task
-	newTask := types.Task{
-		ID:       firstTaskID,
-		ParentID: nextMilestoneID,
-		Title:    message,
-		Status:   "done",
-	}
-
-	// Add task to roadmap
-	roadmap.Tasks = append(roadmap.Tasks, newTask)
-
-	// Save roadmap
-	if err := core.SaveRoadmap(roadmap); err != nil {
-		return fmt.Errorf("failed to save roadmap: %w", err)
-	}
-
 	// Create git commit with tag
-	commitMessage := fmt.Sprintf("[%s] %s", firstTaskID, message)
+	commitMessage := core.FormatIDForCommit(firstTaskID) + " " + message
 	if err := gitCommit(commitMessage); err != nil {
 		return fmt.Errorf("git commit failed: %w", err)
 	}
 
 	fmt.Printf("✅ Created milestone: %s - %s\n", nextMilestoneID, title)
 	fmt.Printf("✅ Created commit: %s\n", commitMessage)
+	recordCommit(commitMessage)
 	fmt.Printf("✅ Added task: %s\n", firstTaskID)
 	fmt.Printf("✅ Set working context to: %s\n", nextMilestoneID)
 
@@ -257,7 +231,7 @@ func interactiveCommitWithoutContext(roadmap *types.Roadmap, message string) err
 	case "skip":
 		return regularGitCommit(message)
 	case "auto":
-		return autoCommitWithoutContext(roadmap, message)
+		return autoCommitWithoutContext(message)
 	case "new":
 		fmt.Printf("Create new milestone first with: spiral add milestone\n")
 		return nil
@@ -265,7 +239,7 @@ func interactiveCommitWithoutContext(roadmap *types.Roadmap, message string) err
 		// Try to find the milestone
 		var targetMilestone *types.Milestone
 		for i := range roadmap.Milestones {
-			if roadmap.Milestones[i].ID == choice {
+			if roadmap.Milestones[i].ID == types.NormalizeID(choice) {
 				targetMilestone = &roadmap.Milestones[i]
 				break
 			}
@@ -284,7 +258,7 @@ func interactiveCommitWithoutContext(roadmap *types.Roadmap, message string) err
 			return fmt.Errorf("failed to save context: %w", err)
 		}
 
-		return autoCommitWithContext(roadmap, targetMilestone, message)
+		return autoCommitWithContext(targetMilestone, message)
 	}
 }
 
@@ -301,6 +275,7 @@ func handleContextSwitch(roadmap *types.Roadmap, message string) error {
 	}
 
 	// Find milestone and set context
+	milestoneID = types.NormalizeID(milestoneID)
 	for i := range roadmap.Milestones {
 		if roadmap.Milestones[i].ID == milestoneID {
 			context := types.Context{
@@ -311,7 +286,7 @@ func handleContextSwitch(roadmap *types.Roadmap, message string) error {
 				return fmt.Errorf("failed to save context: %w", err)
 			}
 
-			return autoCommitWithContext(roadmap, &roadmap.Milestones[i], message)
+			return autoCommitWithContext(&roadmap.Milestones[i], message)
 		}
 	}
 
@@ -320,6 +295,15 @@ func handleContextSwitch(roadmap *types.Roadmap, message string) error {
 
 // Helper functions
 
+// autoCommitFamily picks the family for auto-created milestones: S when it is
+// registered, otherwise the first registered family
+func autoCommitFamily(roadmap *types.Roadmap) string {
+	if roadmap.GetFamily("S") != nil {
+		return "S"
+	}
+	return roadmap.FamilyRegistry()[0].Code
+}
+
 func isGitRepo() bool {
 	cmd := exec.Command("git", "status")
 	return cmd.Run() == nil
@@ -339,6 +323,14 @@ func gitCommit(message string) error {
 	return cmd.Run()
 }
 
+// recordCommit remembers a tagged commit in the working context. Failing to
+// record history never fails the commit itself.
+func recordCommit(message string) {
+	if err := config.RecordCommit(message); err != nil {
+		fmt.Printf("⚠️  Could not record commit history: %v\n", err)
+	}
+}
+
 func regularGitCommit(message string) error {
 	return gitCommit(message)
 }
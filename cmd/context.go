@@ -5,7 +5,6 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/thusai/spiral/config"
-	"github.com/thusai/spiral/core"
 	"github.com/thusai/spiral/types"
 	"github.com/urfave/cli/v2"
 )
@@ -35,7 +34,7 @@ func handleContext(c *cli.Context) error {
 
 	// Handle setting context
 	if milestoneID := c.String("id"); milestoneID != "" {
-		return setContext(c, milestoneID)
+		return setContext(c, types.NormalizeID(milestoneID))
 	}
 
 	// Show current context
@@ -44,7 +43,7 @@ func handleContext(c *cli.Context) error {
 
 func setContext(c *cli.Context, milestoneID string) error {
 	// Load roadmap to validate milestone exists
-	roadmap, err := core.LoadRoadmapFromFile("spiral.yml")
+	roadmap, err := roadmapStore.Load()
 	if err != nil {
 		return err
 	}
@@ -79,12 +78,7 @@ func setContext(c *cli.Context, milestoneID string) error {
 	if len(tasks) > 0 {
 		fmt.Printf("\n📝 Active tasks for %s:\n", milestoneID)
 		for _, task := range tasks {
-			statusIcon := "📝"
-			if task.Status == "done" {
-				statusIcon = "✅"
-			} else if task.Status == "in-progress" {
-				statusIcon = "🔄"
-			}
+			statusIcon := taskStatusIcon(task.Status)
 			
 			fmt.Printf("   %s %s - %s [%s]\n", 
 				statusIcon,
@@ -121,7 +115,7 @@ func showContext(c *cli.Context) error {
 	}
 
 	// Load roadmap to get milestone details
-	roadmap, err := core.LoadRoadmapFromFile("spiral.yml")
+	roadmap, err := roadmapStore.Load()
 	if err != nil {
 		return err
 	}
@@ -155,14 +149,7 @@ func showContext(c *cli.Context) error {
 	if len(tasks) > 0 {
 		fmt.Printf("\n📝 Active tasks:\n")
 		for _, task := range tasks {
-			statusIcon := "📝"
-			if task.Status == "done" {
-				statusIcon = "✅"
-			} else if task.Status == "in-progress" {
-				statusIcon = "🔄"
-			} else if task.Status == "blocked" {
-				statusIcon = "🚫"
-			}
+			statusIcon := taskStatusIcon(task.Status)
 			
 			fmt.Printf("   %s %s - %s [%s]\n", 
 				statusIcon,
@@ -186,17 +173,16 @@ func showContext(c *cli.Context) error {
 
 // colorizeStatus returns a colorized version of status strings
 func colorizeStatus(status string) string {
+	// Workflow states carry their own colours
+	for _, field := range []string{types.WorkflowStatus, types.WorkflowCycleStatus, types.WorkflowReleaseStatus} {
+		if workflow := types.WorkflowFor(field); workflow != nil {
+			if state := workflow.State(status); state != nil && state.Color != "" {
+				return color.New(colorAttribute(state.Color, color.Reset)).Sprint(status)
+			}
+		}
+	}
+
 	switch status {
-	case "done":
-		return color.GreenString(status)
-	case "in-progress", "starting":
-		return color.YellowString(status)
-	case "planned":
-		return color.CyanString(status)
-	case "blocked":
-		return color.RedString(status)
-	case "in-cycle":
-		return color.MagentaString(status)
 	case "critical":
 		return color.RedString(status)
 	case "high":
@@ -210,4 +196,28 @@ func colorizeStatus(status string) string {
 	}
 }
 
+// colorAttribute maps a colour name from the config to a terminal colour
+func colorAttribute(name string, fallback color.Attribute) color.Attribute {
+	switch name {
+	case "black":
+		return color.FgBlack
+	case "red":
+		return color.FgRed
+	case "green":
+		return color.FgGreen
+	case "yellow":
+		return color.FgYellow
+	case "blue":
+		return color.FgBlue
+	case "magenta":
+		return color.FgMagenta
+	case "cyan":
+		return color.FgCyan
+	case "white":
+		return color.FgWhite
+	default:
+		return fallback
+	}
+}
+
  
\ No newline at end of file
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/thusai/spiral/core"
+	"github.com/thusai/spiral/types"
+	"github.com/urfave/cli/v2"
+)
+
+// CycleCommand returns the cycle subcommand
+func CycleCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "cycle",
+		Usage: "Start, plan and close cycles",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "start",
+				Usage: "Start a cycle (a planned one, or a new one)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "name", Required: true, Usage: "Cycle name, e.g. \"Sprint 12\""},
+					&cli.StringFlag{Name: "start", Usage: "Start date (defaults to today)"},
+					&cli.StringFlag{Name: "end", Usage: "End date, e.g. 2024-06-14 or +2w"},
+					&cli.Float64Flag{Name: "capacity", Usage: "How many points the cycle can hold"},
+				},
+				Action: startCycle,
+			},
+			{
+				Name:      "plan",
+				Usage:     "Plan items into the active (or next planned) cycle",
+				ArgsUsage: "<id>...",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "cycle", Usage: "Plan into this cycle instead"},
+					&cli.BoolFlag{Name: "remove", Usage: "Take the items out of the cycle"},
+				},
+				Action: planCycle,
+			},
+			{
+				Name:  "close",
+				Usage: "Close the active cycle and carry unfinished items forward",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "next", Usage: "Cycle to carry unfinished items into (created if missing)"},
+				},
+				Action: closeCycle,
+			},
+			{
+				Name:   "list",
+				Usage:  "List cycles",
+				Action: listCycles,
+			},
+		},
+	}
+}
+
+func startCycle(c *cli.Context) error {
+	now := time.Now()
+	start, err := types.ParseDate(c.String("start"), now)
+	if err != nil {
+		return fmt.Errorf("invalid --start: %w", err)
+	}
+	end, err := types.ParseDate(c.String("end"), now)
+	if err != nil {
+		return fmt.Errorf("invalid --end: %w", err)
+	}
+
+	var cycle *types.Cycle
+	_, err = core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		var err error
+		cycle, err = core.StartCycle(roadmap, c.String("name"), start, end, c.Float64("capacity"))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🚀 Started cycle %s (%s)\n", color.CyanString(cycle.Name), cycleDates(cycle))
+	if len(cycle.Items) == 0 {
+		fmt.Println("   Plan work into it with: spiral cycle plan <id>...")
+	}
+	return nil
+}
+
+func planCycle(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return fmt.Errorf("usage: spiral cycle plan [--cycle=NAME] [--remove] <id>...")
+	}
+	ids := make([]string, c.NArg())
+	for i, arg := range c.Args().Slice() {
+		ids[i] = types.NormalizeID(arg)
+	}
+
+	plan, verb := core.PlanCycleItems, "Planned"
+	if c.Bool("remove") {
+		plan, verb = core.UnplanCycleItems, "Removed"
+	}
+	var cycle *types.Cycle
+	roadmap, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		var err error
+		cycle, err = plan(roadmap, c.String("cycle"), ids)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	preposition := "into"
+	if c.Bool("remove") {
+		preposition = "from"
+	}
+	fmt.Printf("📌 %s %s %s %s\n", verb, strings.Join(ids, ", "), preposition, color.CyanString(cycle.Name))
+	printCycleLoad(roadmap, cycle)
+	return nil
+}
+
+func closeCycle(c *cli.Context) error {
+	var cycle *types.Cycle
+	_, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		var err error
+		cycle, err = core.CloseCycle(roadmap, c.String("next"))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	summary := cycle.Summary
+	fmt.Printf("🏁 Closed cycle %s\n", color.CyanString(cycle.Name))
+	fmt.Printf("   Finished: %d item(s), %s of %s points\n",
+		len(summary.Finished), formatPoints(summary.CompletedPoints), formatPoints(summary.PlannedPoints))
+	if len(summary.CarriedOver) > 0 {
+		fmt.Printf("   Carried over to %s: %s\n", color.CyanString(summary.CarriedTo), strings.Join(summary.CarriedOver, ", "))
+	}
+	return nil
+}
+
+func listCycles(c *cli.Context) error {
+	roadmap, err := roadmapStore.Load()
+	if err != nil {
+		return err
+	}
+
+	if len(roadmap.Cycles) == 0 {
+		fmt.Println("No cycles yet. Start one with:")
+		fmt.Println("  spiral cycle start --name='Sprint 1' --end=+2w --capacity=20")
+		return nil
+	}
+
+	fmt.Println("🔄 Cycles:")
+	for i := range roadmap.Cycles {
+		cycle := &roadmap.Cycles[i]
+		effort := core.CycleEffort(roadmap, cycle)
+		if cycle.Summary != nil {
+			effort = core.Effort{Total: cycle.Summary.PlannedPoints, Completed: cycle.Summary.CompletedPoints}
+		}
+		fmt.Printf("   %s %s [%s] %s, %d item(s), %s/%s pts\n",
+			cycleIcon(cycle.Status),
+			color.CyanString(cycle.Name),
+			cycle.Status,
+			cycleDates(cycle),
+			len(cycle.Items),
+			formatPoints(effort.Completed),
+			formatPoints(effort.Total))
+	}
+	return nil
+}
+
+// showActiveCycle presents the running cycle's progress against capacity
+func showActiveCycle(roadmap *types.Roadmap, cycle *types.Cycle) {
+	fmt.Printf("🔄 %s (%s)\n", color.CyanString(cycle.Name), cycleDates(cycle))
+	fmt.Println(strings.Repeat("=", 40))
+
+	if days, err := cycle.End.DaysFrom(time.Now()); err == nil {
+		switch {
+		case days < 0:
+			fmt.Printf("⏰ %s\n", color.RedString("Ended %d day(s) ago; close it with: spiral cycle close", -days))
+		default:
+			fmt.Printf("⏳ %d day(s) left\n", days)
+		}
+	}
+	printCycleLoad(roadmap, cycle)
+
+	if len(cycle.Items) == 0 {
+		fmt.Println("\nNothing planned yet: spiral cycle plan <id>...")
+		return
+	}
+
+	fmt.Println()
+	for _, id := range cycle.Items {
+		status, _ := roadmap.StatusOf(id)
+		fmt.Printf("   %s %s - %s [%s]\n", taskStatusIcon(status), color.YellowString(id), roadmap.TitleOf(id), colorizeStatus(status))
+	}
+}
+
+// printCycleLoad prints planned and completed points against capacity
+func printCycleLoad(roadmap *types.Roadmap, cycle *types.Cycle) {
+	effort := core.CycleEffort(roadmap, cycle)
+
+	if cycle.Capacity == 0 {
+		fmt.Printf("📐 %s of %s points done\n", formatPoints(effort.Completed), formatPoints(effort.Total))
+		return
+	}
+
+	load := fmt.Sprintf("%s/%s pts planned", formatPoints(effort.Total), formatPoints(cycle.Capacity))
+	if effort.Total > cycle.Capacity {
+		load = color.RedString("%s (over capacity by %s)", load, formatPoints(effort.Total-cycle.Capacity))
+	}
+	fmt.Printf("📐 %s, %s done %s\n", load, formatPoints(effort.Completed), progressBar(effort.Completed, cycle.Capacity, 20))
+}
+
+// progressBar renders done/total as a bar of the given width
+func progressBar(done, total float64, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = int(done / total * float64(width))
+	}
+	if filled > width {
+		filled = width
+	}
+	return "[" + color.GreenString(strings.Repeat("█", filled)) + strings.Repeat("░", width-filled) + "]"
+}
+
+// cycleDates renders "2024-06-01 → 2024-06-14", or what's known of it
+func cycleDates(cycle *types.Cycle) string {
+	switch {
+	case cycle.Start.IsSet() && cycle.End.IsSet():
+		return fmt.Sprintf("%s → %s", cycle.Start, cycle.End)
+	case cycle.Start.IsSet():
+		return fmt.Sprintf("from %s", cycle.Start)
+	default:
+		return "no dates"
+	}
+}
+
+// cycleIcon returns the icon for a cycle status
+func cycleIcon(status string) string {
+	switch status {
+	case types.CycleActive:
+		return "🔄"
+	case types.CycleClosed:
+		return "🏁"
+	default:
+		return "📋"
+	}
+}
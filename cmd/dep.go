@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/thusai/spiral/core"
+	"github.com/thusai/spiral/types"
+	"github.com/urfave/cli/v2"
+)
+
+// DepCommand returns the dep subcommand
+func DepCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "dep",
+		Usage: "Manage dependencies between roadmap items",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "add",
+				Usage:     "Record that an item is blocked by another",
+				ArgsUsage: "<id> <depends-on-id>",
+				Action:    addDependency,
+			},
+			{
+				Name:      "rm",
+				Usage:     "Remove a dependency",
+				ArgsUsage: "<id> <depends-on-id>",
+				Action:    removeDependency,
+			},
+			{
+				Name:      "show",
+				Usage:     "Show what an item depends on and what depends on it",
+				ArgsUsage: "<id>",
+				Action:    showDependencies,
+			},
+		},
+	}
+}
+
+func addDependency(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return fmt.Errorf("usage: spiral dep add <id> <depends-on-id>")
+	}
+	id, dependsOn := types.NormalizeID(c.Args().Get(0)), types.NormalizeID(c.Args().Get(1))
+
+	roadmap, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		return core.AddDependency(roadmap, id, dependsOn)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔗 %s now depends on %s (%s)\n", id, dependsOn, roadmap.TitleOf(dependsOn))
+	return nil
+}
+
+func removeDependency(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return fmt.Errorf("usage: spiral dep rm <id> <depends-on-id>")
+	}
+	id, dependsOn := types.NormalizeID(c.Args().Get(0)), types.NormalizeID(c.Args().Get(1))
+
+	_, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		return core.RemoveDependency(roadmap, id, dependsOn)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✂️  %s no longer depends on %s\n", id, dependsOn)
+	return nil
+}
+
+func showDependencies(c *cli.Context) error {
+	id := types.NormalizeID(c.Args().First())
+	if id == "" {
+		return fmt.Errorf("usage: spiral dep show <id>")
+	}
+
+	roadmap, err := roadmapStore.Load()
+	if err != nil {
+		return err
+	}
+
+	details := roadmap.DetailsOf(id)
+	if details == nil {
+		return fmt.Errorf("item %s not found", id)
+	}
+
+	fmt.Printf("🔗 %s - %s\n", color.CyanString(id), roadmap.TitleOf(id))
+
+	fmt.Println("\nDepends on:")
+	if len(details.DependsOn) == 0 {
+		fmt.Println("   (nothing)")
+	}
+	for _, dep := range details.DependsOn {
+		printDependencyLine(roadmap, dep)
+	}
+
+	fmt.Println("\nRequired by:")
+	dependents := core.Dependents(roadmap, id)
+	if len(dependents) == 0 {
+		fmt.Println("   (nothing)")
+	}
+	for _, dependent := range dependents {
+		printDependencyLine(roadmap, dependent)
+	}
+
+	if unfinished := core.UnfinishedDependencies(roadmap, id); len(unfinished) > 0 {
+		fmt.Printf("\n⛔ Blocked until %d item(s) finish\n", len(unfinished))
+	}
+
+	return nil
+}
+
+// printDependencyLine prints one item of a dependency listing with its status
+func printDependencyLine(roadmap *types.Roadmap, id string) {
+	status, _ := roadmap.StatusOf(id)
+	fmt.Printf("   %s %s - %s [%s]\n",
+		taskStatusIcon(status),
+		color.YellowString(id),
+		roadmap.TitleOf(id),
+		colorizeStatus(status))
+}
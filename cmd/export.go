@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/thusai/spiral/types"
+	"github.com/urfave/cli/v2"
+)
+
+// ExportCommand returns the export subcommand
+func ExportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "Export the roadmap as Markdown or JSON",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "format", Value: "markdown", Usage: "Output format: markdown or json"},
+			&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "Write to `FILE` instead of stdout"},
+		},
+		Action: exportRoadmap,
+	}
+}
+
+func exportRoadmap(c *cli.Context) error {
+	roadmap, err := roadmapStore.Load()
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	switch c.String("format") {
+	case "markdown", "md":
+		data = []byte(exportMarkdown(roadmap))
+	case "json":
+		data, err = json.MarshalIndent(roadmap, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal roadmap to JSON: %w", err)
+		}
+		data = append(data, '\n')
+	default:
+		return fmt.Errorf("unknown export format: %s (use markdown or json)", c.String("format"))
+	}
+
+	if path := c.String("output"); path != "" {
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		fmt.Printf("📤 Exported roadmap to %s\n", path)
+		return nil
+	}
+
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+// exportMarkdown renders the roadmap as a Markdown document: a section per
+// milestone with its tasks as a nested checklist
+func exportMarkdown(roadmap *types.Roadmap) string {
+	var b strings.Builder
+	b.WriteString("# Roadmap\n")
+
+	milestones := append([]types.Milestone{}, roadmap.Milestones...)
+	sort.Slice(milestones, func(i, j int) bool {
+		return types.CompareIDs(milestones[i].ID, milestones[j].ID) < 0
+	})
+
+	for _, milestone := range milestones {
+		fmt.Fprintf(&b, "\n## %s %s\n\n", milestone.ID, milestone.Title)
+
+		facts := []string{"**Status:** " + milestone.Status}
+		if milestone.Version != "" {
+			facts = append(facts, "**Release:** "+milestone.Version)
+		}
+		if end := milestone.End(); end.IsSet() {
+			facts = append(facts, "**Due:** "+string(end))
+		}
+		if len(milestone.Assignees) > 0 {
+			facts = append(facts, "**Assignees:** "+strings.Join(milestone.Assignees, ", "))
+		}
+		if len(milestone.Labels) > 0 {
+			facts = append(facts, "**Labels:** "+strings.Join(milestone.Labels, ", "))
+		}
+		b.WriteString(strings.Join(facts, " · ") + "\n")
+
+		if milestone.Notes != "" {
+			fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(milestone.Notes))
+		}
+		if len(milestone.Links) > 0 {
+			b.WriteString("\n")
+			for _, link := range milestone.Links {
+				fmt.Fprintf(&b, "- %s\n", markdownLink(link))
+			}
+		}
+
+		if tasks := roadmap.GetTasksByParentID(milestone.ID); len(tasks) > 0 {
+			b.WriteString("\n")
+			writeMarkdownTasks(&b, roadmap, milestone.ID, "")
+		}
+	}
+	return b.String()
+}
+
+// writeMarkdownTasks writes the tasks below parentID as a nested checklist
+func writeMarkdownTasks(b *strings.Builder, roadmap *types.Roadmap, parentID, indent string) {
+	tasks := roadmap.GetTasksByParentID(parentID)
+	sort.Slice(tasks, func(i, j int) bool {
+		return types.CompareIDs(tasks[i].ID, tasks[j].ID) < 0
+	})
+
+	for _, task := range tasks {
+		box := " "
+		if types.IsFinishedStatus(task.Status) {
+			box = "x"
+		}
+		fmt.Fprintf(b, "%s- [%s] %s %s", indent, box, task.ID, task.Title)
+		for _, link := range task.Links {
+			fmt.Fprintf(b, " (%s)", markdownLink(link))
+		}
+		b.WriteString("\n")
+		writeMarkdownTasks(b, roadmap, task.ID, indent+"  ")
+	}
+}
+
+// markdownLink renders a link as "pr: [owner/repo#12](url)", or with an
+// autolink when there is nothing shorter to show
+func markdownLink(link types.Link) string {
+	if link.Label() == link.URL {
+		return fmt.Sprintf("%s: <%s>", link.Kind, link.URL)
+	}
+	return fmt.Sprintf("%s: [%s](%s)", link.Kind, link.Label(), link.URL)
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/thusai/spiral/core"
+	"github.com/thusai/spiral/types"
+	"github.com/urfave/cli/v2"
+)
+
+// FamilyCommand returns the family subcommand
+func FamilyCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "family",
+		Usage: "Manage the product families milestones belong to",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "add",
+				Usage: "Register a new family",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "code", Required: true, Usage: "ID prefix (letters only, e.g. API)"},
+					&cli.StringFlag{Name: "name", Required: true, Usage: "Display name"},
+					&cli.StringFlag{Name: "description", Usage: "What the family covers"},
+					&cli.StringFlag{Name: "owner", Usage: "Person or team responsible"},
+					&cli.StringFlag{Name: "color", Usage: "Display colour: red, green, yellow, blue, magenta, cyan, white"},
+					&cli.StringFlag{Name: "default-priority", Usage: "Priority for new milestones: low, medium, high, critical"},
+				},
+				Action: addFamily,
+			},
+			{
+				Name:   "list",
+				Usage:  "List registered families",
+				Action: listFamilies,
+			},
+			{
+				Name:      "rename",
+				Usage:     "Change a family's display name",
+				ArgsUsage: "<code> <name>",
+				Action:    renameFamily,
+			},
+		},
+		Action: listFamilies,
+	}
+}
+
+func addFamily(c *cli.Context) error {
+	family := types.Family{
+		Code:            c.String("code"),
+		Name:            c.String("name"),
+		Description:     c.String("description"),
+		Owner:           c.String("owner"),
+		Color:           c.String("color"),
+		DefaultPriority: c.String("default-priority"),
+	}
+
+	_, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		return core.AddFamily(roadmap, family)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Added family %s: %s\n", colorizeFamily(&family), family.Name)
+	return nil
+}
+
+func listFamilies(c *cli.Context) error {
+	roadmap, err := roadmapStore.Load()
+	if err != nil {
+		return err
+	}
+
+	families := roadmap.FamilyRegistry()
+	if len(roadmap.Families) == 0 {
+		fmt.Println("🏷️  Default families (add your own with: spiral family add)")
+	} else {
+		fmt.Println("🏷️  Registered families:")
+	}
+	fmt.Println()
+
+	for i := range families {
+		family := &families[i]
+
+		// Count milestones in this family
+		count := 0
+		for _, milestone := range roadmap.Milestones {
+			if milestone.Family == family.Code {
+				count++
+			}
+		}
+
+		fmt.Printf("%s  %s (%d milestone(s))\n", colorizeFamily(family), family.Name, count)
+		if family.Description != "" {
+			fmt.Printf("     %s\n", family.Description)
+		}
+		if family.Owner != "" {
+			fmt.Printf("     Owner: %s\n", family.Owner)
+		}
+		if family.DefaultPriority != "" {
+			fmt.Printf("     Default priority: %s\n", colorizeStatus(family.DefaultPriority))
+		}
+	}
+
+	return nil
+}
+
+func renameFamily(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return fmt.Errorf("usage: spiral family rename <code> <name>")
+	}
+	code, name := c.Args().Get(0), c.Args().Get(1)
+
+	_, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		return core.RenameFamily(roadmap, code, name)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Renamed family %s to %s\n", code, name)
+	return nil
+}
+
+// colorizeFamily renders a family code in the family's configured colour
+func colorizeFamily(family *types.Family) string {
+	return color.New(colorAttribute(family.Color, color.FgMagenta)).Sprint(family.Code)
+}
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/thusai/spiral/core"
+	"github.com/thusai/spiral/types"
+	"github.com/urfave/cli/v2"
+)
+
+// GateCommand returns the gate subcommand
+func GateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "gate",
+		Usage:     "Manage the success gates a milestone must pass before it is done",
+		ArgsUsage: "<milestone-id>",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "add",
+				Usage:     "Add a checklist item, or a command with --run",
+				ArgsUsage: "<milestone-id> <name>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "run", Usage: "Shell command that must succeed, e.g. \"go test ./...\""},
+					&cli.Float64Flag{Name: "coverage", Usage: "Minimum coverage percentage the command must report"},
+				},
+				Action: addGate,
+			},
+			{
+				Name:      "rm",
+				Aliases:   []string{"remove"},
+				Usage:     "Remove a gate",
+				ArgsUsage: "<milestone-id> <name>",
+				Action:    removeGate,
+			},
+			{
+				Name:      "check",
+				Usage:     "Tick off a checklist gate",
+				ArgsUsage: "<milestone-id> <name>",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "uncheck", Usage: "Clear the tick instead"},
+				},
+				Action: checkGate,
+			},
+			{
+				Name:      "run",
+				Usage:     "Run a milestone's gates without changing its status",
+				ArgsUsage: "<milestone-id>",
+				Action:    runGates,
+			},
+		},
+		Action: listGates,
+	}
+}
+
+func addGate(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return fmt.Errorf("usage: spiral gate add [--run CMD] [--coverage N] <milestone-id> <name>")
+	}
+	id := types.NormalizeID(c.Args().Get(0))
+	gate := types.Gate{
+		Name:     c.Args().Get(1),
+		Run:      c.String("run"),
+		Coverage: c.Float64("coverage"),
+	}
+
+	_, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		return core.AddGate(roadmap, id, gate)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🚧 Added gate %q to %s: %s\n", gate.Name, color.CyanString(id), describeGate(gate))
+	return nil
+}
+
+func removeGate(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return fmt.Errorf("usage: spiral gate rm <milestone-id> <name>")
+	}
+	id, name := types.NormalizeID(c.Args().Get(0)), c.Args().Get(1)
+
+	_, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		return core.RemoveGate(roadmap, id, name)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🗑️  Removed gate %q from %s\n", name, color.CyanString(id))
+	return nil
+}
+
+func checkGate(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return fmt.Errorf("usage: spiral gate check [--uncheck] <milestone-id> <name>")
+	}
+	id, name := types.NormalizeID(c.Args().Get(0)), c.Args().Get(1)
+	checked := !c.Bool("uncheck")
+
+	_, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		return core.CheckGate(roadmap, id, name, checked)
+	})
+	if err != nil {
+		return err
+	}
+
+	if checked {
+		fmt.Printf("☑️  %s: %q checked off\n", color.CyanString(id), name)
+	} else {
+		fmt.Printf("⬜ %s: %q unchecked\n", color.CyanString(id), name)
+	}
+	return nil
+}
+
+func runGates(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("usage: spiral gate run <milestone-id>")
+	}
+	id := types.NormalizeID(c.Args().First())
+
+	roadmap, err := roadmapStore.Load()
+	if err != nil {
+		return err
+	}
+	milestone := roadmap.GetMilestoneByID(id)
+	if milestone == nil {
+		return fmt.Errorf("milestone %s not found", id)
+	}
+	if len(milestone.Gates) == 0 {
+		fmt.Printf("%s has no gates\n", id)
+		return nil
+	}
+
+	// Run the commands before taking the roadmap lock, then keep the
+	// results whether or not they passed
+	fmt.Printf("🚧 Running gates for %s...\n", color.CyanString(id))
+	defer core.PrepareGates(milestone)()
+	var gateErr *core.GateError
+	roadmap, err = core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		gateErr = nil
+		if err := core.RunGates(roadmap, id); !errors.As(err, &gateErr) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	milestone = roadmap.GetMilestoneByID(id)
+	printGates(milestone)
+	if gateErr != nil {
+		return fmt.Errorf("%d of %d gate(s) not met", len(gateErr.Failed), len(milestone.Gates))
+	}
+	fmt.Printf("✅ All gates pass; %s can be marked %s\n", id, types.GatedStatus())
+	return nil
+}
+
+func listGates(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("usage: spiral gate <milestone-id>")
+	}
+	id := types.NormalizeID(c.Args().First())
+
+	roadmap, err := roadmapStore.Load()
+	if err != nil {
+		return err
+	}
+	milestone := roadmap.GetMilestoneByID(id)
+	if milestone == nil {
+		return fmt.Errorf("milestone %s not found", id)
+	}
+
+	if len(milestone.Gates) == 0 {
+		fmt.Printf("%s has no gates. Add one with:\n", id)
+		fmt.Printf("  spiral gate add --run=\"go test ./...\" %s tests\n", id)
+		return nil
+	}
+
+	fmt.Printf("🚧 Gates for %s - %s:\n", color.CyanString(id), milestone.Title)
+	printGates(milestone)
+	return nil
+}
+
+// printGates lists a milestone's gates with their last known outcome and,
+// for failed commands, the tail of their output
+func printGates(milestone *types.Milestone) {
+	for _, gate := range milestone.Gates {
+		icon := "✅"
+		if !gate.Passed() {
+			icon = "❌"
+			if gate.IsChecklist() || gate.Result == nil {
+				icon = "⬜"
+			}
+		}
+
+		fmt.Printf("   %s %s - %s", icon, gate.Name, describeGate(gate))
+		if problem := gate.Problem(); problem != "" {
+			fmt.Printf(" %s", color.RedString("(%s)", problem))
+		} else if gate.Result != nil && gate.Result.Coverage != nil {
+			fmt.Printf(" %s", color.GreenString("(%g%%)", *gate.Result.Coverage))
+		}
+		fmt.Println()
+
+		if gate.Result != nil && gate.Result.Output != "" {
+			for _, line := range strings.Split(gate.Result.Output, "\n") {
+				fmt.Printf("        %s\n", color.HiBlackString(line))
+			}
+		}
+	}
+}
+
+// describeGate says what a gate checks
+func describeGate(gate types.Gate) string {
+	if gate.IsChecklist() {
+		return "checklist"
+	}
+	if gate.Coverage > 0 {
+		return fmt.Sprintf("`%s`, coverage ≥ %g%%", gate.Run, gate.Coverage)
+	}
+	return fmt.Sprintf("`%s`", gate.Run)
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/thusai/spiral/config"
+	"github.com/thusai/spiral/core"
+	"github.com/thusai/spiral/types"
+	"github.com/urfave/cli/v2"
+)
+
+// HistoryCommand returns the history subcommand
+func HistoryCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "history",
+		Usage:     "Show the change history of an item (or the whole roadmap)",
+		ArgsUsage: "[<id>]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "tree", Usage: "Include changes to everything below the item"},
+			&cli.IntFlag{Name: "limit", Usage: "Only show the most recent `N` changes"},
+		},
+		Action: showHistory,
+	}
+}
+
+func showHistory(c *cli.Context) error {
+	changes, err := config.LoadHistory()
+	if err != nil {
+		return err
+	}
+
+	id := types.NormalizeID(c.Args().First())
+	if id != "" {
+		changes = core.ItemHistory(changes, id, c.Bool("tree"))
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("No history recorded yet")
+		return nil
+	}
+
+	if limit := c.Int("limit"); limit > 0 && len(changes) > limit {
+		changes = changes[len(changes)-limit:]
+	}
+
+	if id != "" {
+		fmt.Printf("📜 History of %s:\n\n", color.CyanString(id))
+	} else {
+		fmt.Println("📜 Roadmap history:")
+		fmt.Println()
+	}
+
+	for _, change := range changes {
+		printChange(change)
+	}
+	return nil
+}
+
+// printChange prints one history entry as "when who id field: old → new"
+func printChange(change types.Change) {
+	who := change.Who
+	if who == "" {
+		who = "unknown"
+	}
+
+	var what string
+	switch {
+	case change.Field == "created":
+		what = fmt.Sprintf("created %q", change.New)
+	case change.Old == "":
+		what = fmt.Sprintf("%s: %s", change.Field, color.GreenString("+ "+change.New))
+	case change.New == "":
+		what = fmt.Sprintf("%s: %s", change.Field, color.RedString("- "+change.Old))
+	default:
+		what = fmt.Sprintf("%s: %s → %s", change.Field, change.Old, change.New)
+	}
+	if change.Note != "" && change.Note != "added" && change.Note != "removed" {
+		what += color.HiBlackString(" (%s)", change.Note)
+	}
+
+	fmt.Printf("%s  %s  %s %s\n",
+		color.HiBlackString(change.At.Local().Format("2006-01-02 15:04")),
+		color.BlueString(who),
+		color.YellowString(change.ItemID),
+		what)
+}
+
+// currentActor names whoever is running spiral: their roster handle, else
+// their git user.email, else their login name
+func currentActor() string {
+	email, err := gitUserEmail()
+	if err != nil {
+		return os.Getenv("USER")
+	}
+	if person := projectConfig.FindPerson(email); person != nil {
+		return person.Handle
+	}
+	return email
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/thusai/spiral/config"
+	"github.com/thusai/spiral/core"
+	"github.com/thusai/spiral/types"
+	"github.com/urfave/cli/v2"
+)
+
+// showItem prints everything the roadmap knows about one milestone or task
+func showItem(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("usage: spiral show item <id>")
+	}
+	id := types.NormalizeID(c.Args().First())
+
+	roadmap, err := roadmapStore.Load()
+	if err != nil {
+		return err
+	}
+
+	details := roadmap.DetailsOf(id)
+	if details == nil {
+		return fmt.Errorf("item %s not found", id)
+	}
+	status, _ := roadmap.StatusOf(id)
+	milestone := roadmap.GetMilestoneByID(id)
+	task := roadmap.GetTaskByID(id)
+
+	icon := taskStatusIcon(status)
+	if milestone != nil {
+		icon = milestoneStatusIcon(milestone)
+	}
+	fmt.Printf("%s %s - %s\n", icon, color.CyanString(id), roadmap.TitleOf(id))
+
+	field := func(label, value string) {
+		if value != "" {
+			fmt.Printf("   %-12s %s\n", label+":", value)
+		}
+	}
+
+	statusLine := colorizeStatus(status)
+	if details.StatusLocked {
+		statusLine += " 🔒"
+	}
+	field("Status", statusLine)
+
+	var notes, priority string
+	var metadata map[string]string
+	if milestone != nil {
+		field("Family", familyTag(roadmap, milestone.Family))
+		field("Cycle", colorizeStatus(milestone.CycleStatus))
+		notes, priority, metadata = milestone.Notes, milestone.Priority, milestone.Metadata
+	} else {
+		field("Parent", color.CyanString(task.ParentID)+" - "+roadmap.TitleOf(task.ParentID))
+		notes, priority, metadata = task.Notes, task.Priority, task.Metadata
+	}
+	if priority != "" {
+		field("Priority", colorizeStatus(priority))
+	}
+	if cycle := roadmap.CycleOf(id); cycle != nil {
+		field("Sprint", cycle.Name)
+	}
+	field("Release", roadmap.ReleaseOf(id))
+	field("Assignees", assigneeNote(details))
+	field("Labels", labelNote(details))
+	field("Effort", effortNote(core.RollupEffort(roadmap)[id]))
+
+	var schedule []string
+	for _, date := range []struct {
+		label string
+		value types.Date
+	}{
+		{"start", details.Start},
+		{"due", details.Due},
+		{"target", details.Target},
+	} {
+		if date.value.IsSet() {
+			schedule = append(schedule, date.label+" "+string(date.value))
+		}
+	}
+	if note := scheduleNote(details, status); note != "" {
+		schedule = append(schedule, note)
+	}
+	field("Schedule", strings.Join(schedule, ", "))
+
+	if len(details.DependsOn) > 0 {
+		deps := strings.Join(details.DependsOn, ", ")
+		if blockers := core.UnfinishedDependencies(roadmap, id); len(blockers) > 0 {
+			deps += " " + blockedByNote(blockers)
+		}
+		field("Depends on", deps)
+	}
+
+	if len(metadata) > 0 {
+		keys := make([]string, 0, len(metadata))
+		for key := range metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for i, key := range keys {
+			keys[i] = key + "=" + metadata[key]
+		}
+		field("Fields", strings.Join(keys, ", "))
+	}
+
+	if notes != "" {
+		fmt.Printf("\n📝 Notes:\n")
+		for _, line := range strings.Split(strings.TrimRight(notes, "\n"), "\n") {
+			fmt.Printf("   %s\n", line)
+		}
+	}
+
+	if len(details.Links) > 0 {
+		fmt.Printf("\n🔗 Links:\n")
+		printLinks(details.Links)
+	}
+
+	if len(details.Comments) > 0 {
+		fmt.Printf("\n💬 Comments:\n")
+		printComments(details.Comments)
+	}
+
+	if milestone != nil && len(milestone.Gates) > 0 {
+		fmt.Printf("\n🚧 Gates:\n")
+		printGates(milestone)
+	}
+
+	if children := roadmap.GetTasksByParentID(id); len(children) > 0 {
+		fmt.Printf("\n🌳 Tasks:\n")
+		printTaskTree(roadmap, core.RollupEffort(roadmap), nil, id, "   ")
+	}
+
+	fmt.Printf("\n🕒 History:\n")
+	printTimestamps(details)
+	if changes, err := config.LoadHistory(); err == nil {
+		if logged := core.ItemHistory(changes, id, false); len(logged) > 0 {
+			fmt.Printf("   %d change(s) logged; see spiral history %s\n", len(logged), id)
+		}
+	}
+	return nil
+}
+
+// printLinks lists links with their kind, title and URL
+func printLinks(links []types.Link) {
+	for _, link := range links {
+		fmt.Printf("   %s %-8s %s", linkIcon(link.Kind), link.Kind, link.Label())
+		if link.Label() != link.URL {
+			fmt.Printf(" %s", color.HiBlackString(link.URL))
+		}
+		fmt.Println()
+	}
+}
+
+// linkIcon returns the icon for a link kind
+func linkIcon(kind string) string {
+	switch kind {
+	case types.LinkPR:
+		return "🔀"
+	case types.LinkIssue:
+		return "🐛"
+	case types.LinkDoc:
+		return "📄"
+	case types.LinkDesign:
+		return "🎨"
+	case types.LinkIncident:
+		return "🚨"
+	default:
+		return "🔗"
+	}
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/thusai/spiral/core"
+	"github.com/thusai/spiral/types"
+	"github.com/urfave/cli/v2"
+)
+
+// LabelCommand returns the label subcommand
+func LabelCommand() *cli.Command {
+	recursive := &cli.BoolFlag{
+		Name:    "recursive",
+		Aliases: []string{"r"},
+		Usage:   "Apply to every item below the ID as well",
+	}
+
+	return &cli.Command{
+		Name:  "label",
+		Usage: "Add, remove or list labels",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "add",
+				Usage:     "Attach labels to an item (or a whole subtree with -r)",
+				ArgsUsage: "<id> <label>...",
+				Flags:     []cli.Flag{recursive},
+				Action:    addLabels,
+			},
+			{
+				Name:      "rm",
+				Usage:     "Remove labels from an item (or a whole subtree with -r)",
+				ArgsUsage: "<id> <label>...",
+				Flags:     []cli.Flag{recursive},
+				Action:    removeLabels,
+			},
+			{
+				Name:   "list",
+				Usage:  "List labels in use with how many items carry each",
+				Action: listLabels,
+			},
+		},
+	}
+}
+
+func addLabels(c *cli.Context) error {
+	return changeLabels(c, "add", core.AddLabels, "🏷️  Labelled")
+}
+
+func removeLabels(c *cli.Context) error {
+	return changeLabels(c, "rm", core.RemoveLabels, "✂️  Unlabelled")
+}
+
+// changeLabels runs a bulk label change over the ID (and its subtree)
+func changeLabels(c *cli.Context, verb string, apply func(*types.Roadmap, []string, []string) (int, error), done string) error {
+	if c.NArg() < 2 {
+		return fmt.Errorf("usage: spiral label %s [-r] <id> <label>...", verb)
+	}
+	id := types.NormalizeID(c.Args().First())
+	labels := c.Args().Slice()[1:]
+
+	var changed int
+	_, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		ids, err := core.LabelTargets(roadmap, id, c.Bool("recursive"))
+		if err != nil {
+			return err
+		}
+		changed, err = apply(roadmap, ids, labels)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if changed == 0 {
+		fmt.Println("Nothing to change")
+		return nil
+	}
+
+	fmt.Printf("%s %d item(s) with %s\n", done, changed, color.MagentaString("#"+strings.Join(labels, " #")))
+	return nil
+}
+
+func listLabels(c *cli.Context) error {
+	roadmap, err := roadmapStore.Load()
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[string]int)
+	for _, milestone := range roadmap.Milestones {
+		for _, label := range milestone.Labels {
+			counts[strings.ToLower(label)]++
+		}
+	}
+	for _, task := range roadmap.Tasks {
+		for _, label := range task.Labels {
+			counts[strings.ToLower(label)]++
+		}
+	}
+
+	if len(counts) == 0 {
+		fmt.Println("No labels in use. Add one with:")
+		fmt.Println("  spiral label add D1 backend")
+		return nil
+	}
+
+	labels := make([]string, 0, len(counts))
+	for label := range counts {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	fmt.Println("🏷️  Labels:")
+	for _, label := range labels {
+		fmt.Printf("   %s %d\n", color.MagentaString("#"+label), counts[label])
+	}
+	return nil
+}
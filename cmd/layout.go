@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/thusai/spiral/core"
+	"github.com/urfave/cli/v2"
+)
+
+// LayoutCommand returns the layout subcommand
+func LayoutCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "layout",
+		Usage: "Keep the roadmap in spiral.yml or split it across .spiral/roadmap",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "split",
+				Usage:  "Move spiral.yml into one file per milestone under .spiral/roadmap/<family>/",
+				Action: splitLayout,
+			},
+			{
+				Name:   "join",
+				Usage:  "Merge .spiral/roadmap back into spiral.yml",
+				Action: joinLayout,
+			},
+		},
+		Action: showLayout,
+	}
+}
+
+func splitLayout(c *cli.Context) error {
+	roadmapPath, err := yamlRoadmapPath()
+	if err != nil {
+		return err
+	}
+	if roadmapPath == core.RoadmapDir {
+		return fmt.Errorf("the roadmap is already split across %s", core.RoadmapDir)
+	}
+
+	files, err := core.SplitRoadmap(roadmapPath, core.RoadmapDir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✂️  Split %s into %d file(s) under %s:\n", roadmapPath, len(files), core.RoadmapDir)
+	for _, file := range files {
+		fmt.Printf("   %s\n", file)
+	}
+	return nil
+}
+
+func joinLayout(c *cli.Context) error {
+	roadmapPath, err := yamlRoadmapPath()
+	if err != nil {
+		return err
+	}
+	if roadmapPath != core.RoadmapDir {
+		return fmt.Errorf("the roadmap is not split; it already lives in %s", roadmapPath)
+	}
+
+	if err := core.JoinRoadmap(core.RoadmapDir, core.RoadmapFile); err != nil {
+		return err
+	}
+
+	fmt.Printf("🧩 Joined %s into %s\n", core.RoadmapDir, core.RoadmapFile)
+	return nil
+}
+
+func showLayout(c *cli.Context) error {
+	roadmapPath, err := yamlRoadmapPath()
+	if err != nil {
+		return err
+	}
+	if roadmapPath != core.RoadmapDir {
+		fmt.Printf("📄 Single file: %s\n", roadmapPath)
+		fmt.Println("   Split it per milestone with: spiral layout split")
+		return nil
+	}
+
+	roadmap, err := roadmapStore.Load()
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[string]int)
+	for _, file := range roadmap.Files {
+		counts[file]++
+	}
+	files := make([]string, 0, len(roadmap.Sources))
+	for file := range roadmap.Sources {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	fmt.Printf("🗂️  Split across %s:\n", roadmapPath)
+	for _, file := range files {
+		fmt.Printf("   %s %s\n", filepath.ToSlash(filepath.Join(roadmapPath, file)), color.HiBlackString("(%d item(s))", counts[file]))
+	}
+	return nil
+}
+
+// yamlRoadmapPath returns the YAML roadmap the layout commands work on
+func yamlRoadmapPath() (string, error) {
+	store, ok := roadmapStore.(*core.YAMLStore)
+	if !ok {
+		return "", fmt.Errorf("layouts apply to the yaml storage backend; this project uses %s in %s",
+			projectConfig.Storage.Backend, roadmapStore.Path())
+	}
+	return store.Path(), nil
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/thusai/spiral/core"
+	"github.com/thusai/spiral/types"
+	"github.com/urfave/cli/v2"
+)
+
+// LinkCommand returns the link subcommand
+func LinkCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "link",
+		Usage: "Point items at issues, pull requests, docs, designs or incidents",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "add",
+				Usage:     "Link an item to a URL (the kind is detected from the URL)",
+				ArgsUsage: "<id> <url>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "kind", Usage: "Link kind: issue, pr, doc, design, incident or link"},
+					&cli.StringFlag{Name: "title", Usage: "Title to show instead of the URL"},
+				},
+				Action: addLink,
+			},
+			{
+				Name:      "rm",
+				Usage:     "Remove a link from an item",
+				ArgsUsage: "<id> <url>",
+				Action:    removeLink,
+			},
+			{
+				Name:      "list",
+				Usage:     "List an item's links",
+				ArgsUsage: "<id>",
+				Action:    listLinks,
+			},
+		},
+	}
+}
+
+func addLink(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return fmt.Errorf("usage: spiral link add [--kind KIND] [--title TITLE] <id> <url>")
+	}
+	id := types.NormalizeID(c.Args().Get(0))
+
+	var link types.Link
+	_, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		var err error
+		link, err = core.AddLink(roadmap, id, types.Link{
+			Kind:  c.String("kind"),
+			URL:   c.Args().Get(1),
+			Title: c.String("title"),
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Linked %s to %s %s\n", linkIcon(link.Kind), color.CyanString(id), link.Kind, link.Label())
+	return nil
+}
+
+func removeLink(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return fmt.Errorf("usage: spiral link rm <id> <url>")
+	}
+	id, url := types.NormalizeID(c.Args().Get(0)), c.Args().Get(1)
+
+	_, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		return core.RemoveLink(roadmap, id, url)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✂️  Unlinked %s from %s\n", url, color.CyanString(id))
+	return nil
+}
+
+func listLinks(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("usage: spiral link list <id>")
+	}
+	id := types.NormalizeID(c.Args().First())
+
+	roadmap, err := roadmapStore.Load()
+	if err != nil {
+		return err
+	}
+
+	details := roadmap.DetailsOf(id)
+	if details == nil {
+		return fmt.Errorf("item %s not found", id)
+	}
+	if len(details.Links) == 0 {
+		fmt.Printf("%s has no links. Add one with:\n", id)
+		fmt.Printf("  spiral link add %s https://github.com/org/repo/pull/42\n", id)
+		return nil
+	}
+
+	fmt.Printf("🔗 Links for %s - %s:\n", color.CyanString(id), roadmap.TitleOf(id))
+	printLinks(details.Links)
+	return nil
+}
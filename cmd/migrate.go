@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/thusai/spiral/core"
+	"github.com/urfave/cli/v2"
+)
+
+// MigrateCommand returns the migrate subcommand
+func MigrateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "migrate",
+		Usage:     "Rewrite a roadmap in an older layout into the current schema",
+		ArgsUsage: "[file]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "dry-run", Usage: "Report what would change without writing the file"},
+		},
+		Action: migrateRoadmap,
+	}
+}
+
+func migrateRoadmap(c *cli.Context) error {
+	store := roadmapStore
+	if c.Args().Present() {
+		store = core.NewYAMLStore(c.Args().First())
+	}
+	filePath := store.Path()
+
+	// Loading performs the conversions; the warning would only repeat the report
+	core.Warn = func(string) {}
+	roadmap, err := store.Load()
+	if err != nil {
+		return err
+	}
+
+	if len(roadmap.Migrated) == 0 {
+		fmt.Printf("✅ %s is already in the current format\n", filePath)
+		return nil
+	}
+
+	fmt.Printf("🔧 Migrating %s:\n", filePath)
+	for _, note := range roadmap.Migrated {
+		fmt.Printf("   • %s\n", note)
+	}
+
+	if c.Bool("dry-run") {
+		fmt.Println("\nDry run: nothing written")
+		return nil
+	}
+
+	if err := store.Save(roadmap); err != nil {
+		return fmt.Errorf("failed to save roadmap: %w", err)
+	}
+
+	fmt.Printf("\n✅ %s now uses schema version %d\n", filePath, roadmap.SchemaVersion)
+	return nil
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/thusai/spiral/core"
+	"github.com/urfave/cli/v2"
+)
+
+// MineCommand returns the mine subcommand
+func MineCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "mine",
+		Usage: "Show items assigned to you (from git user.email)",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "as", Usage: "Show someone else's items (handle, name or email)"},
+			&cli.BoolFlag{Name: "all", Usage: "Include finished items"},
+		},
+		Action: showMine,
+	}
+}
+
+// WorkloadCommand returns the workload subcommand
+func WorkloadCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "workload",
+		Usage:  "Count open items per person",
+		Action: showWorkload,
+	}
+}
+
+func showMine(c *cli.Context) error {
+	who := c.String("as")
+	if who == "" {
+		email, err := gitUserEmail()
+		if err != nil {
+			return fmt.Errorf("could not determine who you are (set git user.email or pass --as): %w", err)
+		}
+		who = email
+	}
+
+	roadmap, err := roadmapStore.Load()
+	if err != nil {
+		return err
+	}
+
+	identities := assigneeIdentities(who)
+	ids := core.AssignedItems(roadmap, identities, c.Bool("all"))
+
+	name := who
+	if person := projectConfig.FindPerson(who); person != nil {
+		name = person.Handle
+	}
+
+	if len(ids) == 0 {
+		fmt.Printf("🙌 Nothing open assigned to %s\n", name)
+		return nil
+	}
+
+	efforts := core.RollupEffort(roadmap)
+
+	fmt.Printf("👤 %d item(s) assigned to %s:\n\n", len(ids), color.BlueString(name))
+	for _, id := range ids {
+		status, _ := roadmap.StatusOf(id)
+
+		note := ""
+		if effort := effortNote(efforts[id]); effort != "" {
+			note += " " + effort
+		}
+		if blockers := core.UnfinishedDependencies(roadmap, id); len(blockers) > 0 {
+			note += " " + blockedByNote(blockers)
+		}
+
+		fmt.Printf("   %s %s - %s [%s]%s\n",
+			taskStatusIcon(status),
+			color.YellowString(id),
+			roadmap.TitleOf(id),
+			colorizeStatus(status),
+			note)
+	}
+
+	return nil
+}
+
+func showWorkload(c *cli.Context) error {
+	roadmap, err := roadmapStore.Load()
+	if err != nil {
+		return err
+	}
+
+	workloads := core.ComputeWorkload(roadmap, projectConfig.People)
+	if len(workloads) == 0 {
+		fmt.Println("No assignees yet. Assign work with: spiral add task --assignee=<handle> ...")
+		return nil
+	}
+
+	fmt.Println("👥 Open work per person:")
+	fmt.Println("========================")
+	for _, load := range workloads {
+		line := fmt.Sprintf("   %-20s %3d open item(s)", load.Assignee, load.OpenItems)
+		if load.RemainingPoints > 0 {
+			line += fmt.Sprintf(", %s pts", formatPoints(load.RemainingPoints))
+		}
+		fmt.Println(line)
+	}
+
+	return nil
+}
+
+// gitUserEmail returns the email configured for git commits
+func gitUserEmail() (string, error) {
+	out, err := exec.Command("git", "config", "user.email").Output()
+	if err != nil {
+		return "", err
+	}
+
+	email := strings.TrimSpace(string(out))
+	if email == "" {
+		return "", fmt.Errorf("git user.email is empty")
+	}
+	return email, nil
+}
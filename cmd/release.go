@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/thusai/spiral/core"
+	"github.com/thusai/spiral/types"
+	"github.com/urfave/cli/v2"
+)
+
+// ReleaseCommand returns the release subcommand
+func ReleaseCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "release",
+		Usage: "Track releases: scope, progress and risk",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "add",
+				Usage: "Define a release",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "name", Required: true, Usage: "Release name, e.g. v1.2"},
+					&cli.StringFlag{Name: "target", Usage: "Target date (YYYY-MM-DD or e.g. \"in 6 weeks\")"},
+					&cli.StringFlag{Name: "notes", Usage: "Release notes"},
+				},
+				Action: addRelease,
+			},
+			{
+				Name:      "assign",
+				Usage:     "Ship milestones in a release",
+				ArgsUsage: "<release> <milestone-id>...",
+				Action:    assignRelease,
+			},
+			{
+				Name:      "unassign",
+				Usage:     "Take milestones out of their release",
+				ArgsUsage: "<milestone-id>...",
+				Action:    unassignRelease,
+			},
+			{
+				Name:      "status",
+				Usage:     "Change a release's status",
+				ArgsUsage: "<release> <status>",
+				Action:    setReleaseStatus,
+			},
+			{
+				Name:      "show",
+				Usage:     "Show one release in detail",
+				ArgsUsage: "<release>",
+				Action:    showRelease,
+			},
+		},
+		Action: listReleases,
+	}
+}
+
+func addRelease(c *cli.Context) error {
+	target, err := types.ParseDate(c.String("target"), time.Now())
+	if err != nil {
+		return fmt.Errorf("invalid --target: %w", err)
+	}
+
+	release := types.Release{Name: c.String("name"), Target: target, Notes: c.String("notes")}
+	_, err = core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		return core.AddRelease(roadmap, release)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("📦 Added release %s", color.CyanString(release.Name))
+	if target.IsSet() {
+		fmt.Printf(" targeting %s", target)
+	}
+	fmt.Println()
+	return nil
+}
+
+func assignRelease(c *cli.Context) error {
+	if c.NArg() < 2 {
+		return fmt.Errorf("usage: spiral release assign <release> <milestone-id>...")
+	}
+	return changeReleaseAssignment(c.Args().First(), c.Args().Slice()[1:])
+}
+
+func unassignRelease(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return fmt.Errorf("usage: spiral release unassign <milestone-id>...")
+	}
+	return changeReleaseAssignment("", c.Args().Slice())
+}
+
+// changeReleaseAssignment moves milestones into a release, or out of one
+// when name is empty
+func changeReleaseAssignment(name string, args []string) error {
+	ids := make([]string, len(args))
+	for i, arg := range args {
+		ids[i] = types.NormalizeID(arg)
+	}
+
+	_, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		return core.AssignRelease(roadmap, name, ids)
+	})
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		fmt.Printf("📦 %s no longer in a release\n", strings.Join(ids, ", "))
+	} else {
+		fmt.Printf("📦 %s now ship in %s\n", strings.Join(ids, ", "), color.CyanString(name))
+	}
+	return nil
+}
+
+func setReleaseStatus(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return fmt.Errorf("usage: spiral release status <release> <status>")
+	}
+	name, status := c.Args().Get(0), c.Args().Get(1)
+
+	_, err := core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		return core.SetReleaseStatus(roadmap, name, status)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("📦 %s is now %s\n", color.CyanString(name), colorizeStatus(status))
+	return nil
+}
+
+func listReleases(c *cli.Context) error {
+	roadmap, err := roadmapStore.Load()
+	if err != nil {
+		return err
+	}
+
+	if len(roadmap.Releases) == 0 {
+		fmt.Println("No releases yet. Define one with:")
+		fmt.Println("  spiral release add --name=v1.0 --target=2024-09-01")
+		return nil
+	}
+
+	fmt.Println("📦 Releases:")
+	now := time.Now()
+	for i := range roadmap.Releases {
+		report := core.ReportRelease(roadmap, &roadmap.Releases[i], now)
+		printReleaseLine(report)
+		for _, reason := range report.Reasons {
+			fmt.Printf("      ⚠️  %s\n", reason)
+		}
+	}
+	return nil
+}
+
+func showRelease(c *cli.Context) error {
+	name := c.Args().First()
+	if name == "" {
+		return fmt.Errorf("usage: spiral release show <release>")
+	}
+
+	roadmap, err := roadmapStore.Load()
+	if err != nil {
+		return err
+	}
+
+	release := roadmap.GetRelease(name)
+	if release == nil {
+		return fmt.Errorf("release %s not found", name)
+	}
+
+	report := core.ReportRelease(roadmap, release, time.Now())
+	printReleaseLine(report)
+	if release.Notes != "" {
+		fmt.Printf("   %s\n", release.Notes)
+	}
+	for _, reason := range report.Reasons {
+		fmt.Printf("   ⚠️  %s\n", reason)
+	}
+
+	fmt.Println("\nScope:")
+	if len(report.Milestones) == 0 {
+		fmt.Printf("   (nothing yet: spiral release assign %s <milestone-id>...)\n", release.Name)
+	}
+	efforts := core.RollupEffort(roadmap)
+	for _, milestone := range report.Milestones {
+		fmt.Printf("   %s %s - %s [%s] %s\n",
+			milestoneStatusIcon(milestone),
+			color.CyanString(milestone.ID),
+			milestone.Title,
+			colorizeStatus(milestone.Status),
+			effortNote(efforts[milestone.ID]))
+	}
+	return nil
+}
+
+// printReleaseLine prints a release with its progress and risk
+func printReleaseLine(report core.ReleaseReport) {
+	release := report.Release
+
+	var when string
+	switch {
+	case report.Risk == core.RiskShipped:
+		when = "shipped"
+		if release.Target.IsSet() {
+			when = fmt.Sprintf("targeted %s", release.Target)
+		}
+	case report.DaysLeft == nil:
+		when = "no target"
+	case *report.DaysLeft >= 0:
+		when = fmt.Sprintf("%s, %d day(s) left", release.Target, *report.DaysLeft)
+	default:
+		when = fmt.Sprintf("%s, %d day(s) ago", release.Target, -*report.DaysLeft)
+	}
+
+	progress := fmt.Sprintf("%d/%d milestones", report.Finished, len(report.Milestones))
+	if report.Effort.Total > 0 {
+		progress += fmt.Sprintf(", %s/%s pts", formatPoints(report.Effort.Completed), formatPoints(report.Effort.Total))
+	}
+
+	fmt.Printf("   %s %s [%s] %s — %s — %s\n",
+		riskIcon(report.Risk),
+		color.CyanString(release.Name),
+		colorizeStatus(release.Status),
+		when,
+		progress,
+		colorizeRisk(report.Risk))
+}
+
+// riskIcon returns the icon for a release risk level
+func riskIcon(risk string) string {
+	switch risk {
+	case core.RiskShipped:
+		return "🚢"
+	case core.RiskLate:
+		return "🔥"
+	case core.RiskAtRisk:
+		return "⚠️ "
+	default:
+		return "📦"
+	}
+}
+
+// colorizeRisk colours a release risk level
+func colorizeRisk(risk string) string {
+	switch risk {
+	case core.RiskLate:
+		return color.RedString(risk)
+	case core.RiskAtRisk:
+		return color.YellowString(risk)
+	default:
+		return color.GreenString(risk)
+	}
+}
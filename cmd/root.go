@@ -2,16 +2,81 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 
+	"github.com/thusai/spiral/config"
+	"github.com/thusai/spiral/core"
+	"github.com/thusai/spiral/types"
 	"github.com/urfave/cli/v2"
 )
 
+// projectConfig is the project configuration loaded by Setup
+var projectConfig types.Config
+
+// roadmapStore keeps the project's roadmap in the backend its storage
+// config selects
+var roadmapStore core.Store = core.NewYAMLStore(core.RoadmapFile)
+
+// Setup applies the project configuration before any command runs
+func Setup(c *cli.Context) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+	projectConfig = cfg
+
+	if cfg.MaxDepth > 0 {
+		types.MaxDepth = cfg.MaxDepth
+	}
+	types.Grammar = cfg.IDs
+	if len(cfg.SizePoints) > 0 {
+		types.SizePoints = cfg.SizePoints
+	}
+	if err := types.ValidateCustomFields(cfg.CustomFields); err != nil {
+		return fmt.Errorf("invalid custom_fields in config: %w", err)
+	}
+	types.CustomFields = cfg.CustomFields
+	types.Workflows = make(map[string]*types.Workflow)
+	for field, workflow := range types.DefaultWorkflows {
+		types.Workflows[field] = workflow
+	}
+
+	for field, workflow := range cfg.Workflows {
+		if err := workflow.Validate(); err != nil {
+			return fmt.Errorf("invalid %s workflow in config: %w", field, err)
+		}
+		types.Workflows[field] = workflow
+	}
+
+	// Rollup rules name status states, so they are checked once the
+	// workflows are in place
+	if len(cfg.StatusRollup) > 0 {
+		if err := types.ValidateRollupRules(cfg.StatusRollup, types.WorkflowFor(types.WorkflowStatus)); err != nil {
+			return fmt.Errorf("invalid status_rollup in config: %w", err)
+		}
+		types.RollupRules = cfg.StatusRollup
+	}
+
+	store, err := core.OpenStore(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("invalid storage in config: %w", err)
+	}
+	roadmapStore = store
+
+	core.Actor = currentActor()
+	core.Warn = func(message string) {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", message)
+	}
+
+	return nil
+}
+
 // DefaultAction handles the case when spiral is called without subcommands
 func DefaultAction(c *cli.Context) error {
 	// Show current config info
 	fmt.Println("🎯 Welcome to Spiral!")
-	fmt.Printf("📋 Active Roadmap: %s\n", "spiral.yml")
+	fmt.Printf("📋 Active Roadmap: %s\n", roadmapStore.Path())
 	fmt.Println("")
 	fmt.Println("Quick actions:")
 	fmt.Println("  spiral show all                  # View roadmap")
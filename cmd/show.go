@@ -2,7 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"os/signal"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/thusai/spiral/core"
@@ -21,36 +26,76 @@ func ShowCommand() *cli.Command {
 			&cli.StringFlag{Name: "priority", Usage: "Filter by priority"},
 			&cli.StringFlag{Name: "status", Usage: "Filter by status"},
 			&cli.StringFlag{Name: "cycle-status", Usage: "Filter by cycle status"},
+			&cli.StringFlag{Name: "assignee", Usage: "Filter by assignee (handle, name or email)"},
+			&cli.StringFlag{Name: "release", Usage: "Filter by release (milestones shipping in it and their tasks)"},
+			&cli.BoolFlag{Name: "overdue", Usage: "Only show unfinished items past their due or target date"},
+			&cli.IntFlag{Name: "due-soon", Usage: "Only show unfinished items due within `DAYS` days"},
+			&cli.StringSliceFlag{Name: "field", Usage: "Filter by custom field `KEY=VALUE` (repeatable)"},
+			&cli.StringFlag{Name: "columns", Usage: "Comma-separated custom fields to show as columns"},
+			&cli.StringSliceFlag{Name: "label", Usage: "Filter by label `EXPR`, e.g. \"backend & !spike\" (repeatable, all must match)"},
+			&cli.BoolFlag{Name: "watch", Usage: "Redraw whenever the roadmap changes (Ctrl-C to stop)"},
 		},
 		Subcommands: []*cli.Command{
 			{
 				Name:   "all",
 				Usage:  "Show complete roadmap with hierarchy",
-				Action: showAll,
+				Action: watchable(showAll),
 			},
 			{
 				Name:   "milestones",
 				Usage:  "Show milestones",
-				Action: showMilestones,
+				Action: watchable(showMilestones),
 			},
 			{
 				Name:   "tasks",
 				Usage:  "Show tasks",
-				Action: showTasks,
+				Action: watchable(showTasks),
 			},
 			{
 				Name:   "cycle",
 				Usage:  "Show current cycle status",
-				Action: showCycle,
+				Action: watchable(showCycle),
+			},
+			{
+				Name:      "item",
+				Usage:     "Show everything about one milestone or task",
+				ArgsUsage: "<id>",
+				Action:    watchable(showItem),
 			},
 		},
-		Action: showAll, // Default action
+		Action: watchable(showAll), // Default action
+	}
+}
+
+// watchable runs a show action once, or with --watch again each time the
+// roadmap changes
+func watchable(action cli.ActionFunc) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if !c.Bool("watch") {
+			return action(c)
+		}
+
+		ctx, stop := signal.NotifyContext(c.Context, os.Interrupt)
+		defer stop()
+		redraw := func() {
+			fmt.Print("\033[H\033[2J")
+			if err := action(c); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+		}
+		redraw()
+		return roadmapStore.Watch(ctx, redraw)
 	}
 }
 
 func showMilestones(c *cli.Context) error {
+	filter, err := newShowFilter(c)
+	if err != nil {
+		return err
+	}
+
 	// Load roadmap
-	roadmap, err := core.LoadRoadmapFromFile("spiral.yml")
+	roadmap, err := roadmapStore.Load()
 	if err != nil {
 		return err
 	}
@@ -58,14 +103,14 @@ func showMilestones(c *cli.Context) error {
 	// Apply filters
 	var filtered []types.Milestone
 	for _, milestone := range roadmap.Milestones {
-		if matchesMilestoneFilters(milestone, c) {
+		if filter.matchesMilestone(roadmap, &milestone) {
 			filtered = append(filtered, milestone)
 		}
 	}
 
 	// Sort by ID for logical flow
 	sort.Slice(filtered, func(i, j int) bool {
-		return filtered[i].ID < filtered[j].ID
+		return types.CompareIDs(filtered[i].ID, filtered[j].ID) < 0
 	})
 
 	// Display
@@ -75,14 +120,19 @@ func showMilestones(c *cli.Context) error {
 	}
 
 	fmt.Printf("📋 Found %d milestone(s):\n\n", len(filtered))
-	displayMilestonesTable(filtered)
+	displayMilestonesTable(roadmap, filtered, c)
 
 	return nil
 }
 
 func showTasks(c *cli.Context) error {
+	filter, err := newShowFilter(c)
+	if err != nil {
+		return err
+	}
+
 	// Load roadmap
-	roadmap, err := core.LoadRoadmapFromFile("spiral.yml")
+	roadmap, err := roadmapStore.Load()
 	if err != nil {
 		return err
 	}
@@ -90,14 +140,14 @@ func showTasks(c *cli.Context) error {
 	// Apply filters
 	var filtered []types.Task
 	for _, task := range roadmap.Tasks {
-		if matchesTaskFilters(task, c) {
+		if filter.matchesTask(roadmap, &task) {
 			filtered = append(filtered, task)
 		}
 	}
 
 	// Sort by ID
 	sort.Slice(filtered, func(i, j int) bool {
-		return filtered[i].ID < filtered[j].ID
+		return types.CompareIDs(filtered[i].ID, filtered[j].ID) < 0
 	})
 
 	// Display
@@ -107,22 +157,28 @@ func showTasks(c *cli.Context) error {
 	}
 
 	fmt.Printf("📝 Found %d task(s):\n\n", len(filtered))
-	displayTasksTable(filtered)
+	displayTasksTable(roadmap, filtered, c)
 
 	return nil
 }
 
 func showCycle(c *cli.Context) error {
 	// Load roadmap
-	roadmap, err := core.LoadRoadmapFromFile("spiral.yml")
+	roadmap, err := roadmapStore.Load()
 	if err != nil {
 		return err
 	}
 
+	// A running cycle entity takes precedence over per-milestone cycle status
+	if cycle := roadmap.ActiveCycle(); cycle != nil {
+		showActiveCycle(roadmap, cycle)
+		return nil
+	}
+
 	// Get in-cycle milestones
 	var inCycleMilestones []types.Milestone
 	for _, milestone := range roadmap.Milestones {
-		if milestone.CycleStatus == "in-cycle" {
+		if types.WorkflowFor(types.WorkflowCycleStatus).IsWorking(milestone.CycleStatus) {
 			inCycleMilestones = append(inCycleMilestones, milestone)
 		}
 	}
@@ -138,13 +194,19 @@ func showCycle(c *cli.Context) error {
 		return nil
 	}
 
+	efforts := core.RollupEffort(roadmap)
+	var cycleEffort core.Effort
+
 	// Show in-cycle milestones with their tasks
 	for _, milestone := range inCycleMilestones {
+		cycleEffort.Add(efforts[milestone.ID])
+
 		coloredStatus := colorizeStatus(milestone.CycleStatus)
-		fmt.Printf("\n📋 %s - %s [%s]\n", 
+		fmt.Printf("\n📋 %s - %s [%s] %s\n", 
 			color.CyanString(milestone.ID), 
 			milestone.Title, 
-			coloredStatus)
+			coloredStatus,
+			effortNote(efforts[milestone.ID]))
 
 		// Show tasks for this milestone
 		milestoneTasks := roadmap.GetTasksByParentID(milestone.ID)
@@ -164,13 +226,24 @@ func showCycle(c *cli.Context) error {
 	// Show summary
 	fmt.Printf("\n📊 Summary: %d milestones, %d tasks in cycle\n", 
 		len(inCycleMilestones), len(inCycleTasks))
+	if cycleEffort.Total > 0 {
+		fmt.Printf("📐 Effort: %s of %s points done, %s remaining\n",
+			formatPoints(cycleEffort.Completed),
+			formatPoints(cycleEffort.Total),
+			formatPoints(cycleEffort.Remaining()))
+	}
 
 	return nil
 }
 
 func showAll(c *cli.Context) error {
+	filter, err := newShowFilter(c)
+	if err != nil {
+		return err
+	}
+
 	// Load roadmap
-	roadmap, err := core.LoadRoadmapFromFile("spiral.yml")
+	roadmap, err := roadmapStore.Load()
 	if err != nil {
 		return err
 	}
@@ -180,7 +253,7 @@ func showAll(c *cli.Context) error {
 
 	// Sort milestones by ID for logical flow
 	sort.Slice(roadmap.Milestones, func(i, j int) bool {
-		return roadmap.Milestones[i].ID < roadmap.Milestones[j].ID
+		return types.CompareIDs(roadmap.Milestones[i].ID, roadmap.Milestones[j].ID) < 0
 	})
 
 	if len(roadmap.Milestones) == 0 {
@@ -189,22 +262,29 @@ func showAll(c *cli.Context) error {
 		return nil
 	}
 
+	visible := filter.visible(roadmap)
+	if len(visible) == 0 {
+		fmt.Println("No milestones found matching filters")
+		return nil
+	}
+
+	efforts := core.RollupEffort(roadmap)
+
 	// Display each milestone with its tasks
 	for _, milestone := range roadmap.Milestones {
-		// Format milestone
-		statusIcon := "📋"
-		if milestone.CycleStatus == "in-cycle" {
-			statusIcon = "🔄"
-		} else if milestone.Status == "done" {
-			statusIcon = "✅"
+		if !visible[milestone.ID] {
+			continue
 		}
 
+		// Format milestone
+		statusIcon := milestoneStatusIcon(&milestone)
+
 		// Build milestone line
 		var parts []string
 		parts = append(parts, color.CyanString(milestone.ID))
 		
 		if milestone.Family != "" {
-			parts = append(parts, color.MagentaString("["+milestone.Family+"]"))
+			parts = append(parts, familyTag(roadmap, milestone.Family))
 		}
 		
 		parts = append(parts, milestone.Title)
@@ -217,73 +297,351 @@ func showAll(c *cli.Context) error {
 			parts = append(parts, colorizeStatus(milestone.CycleStatus))
 		}
 
-		// Print milestone
-		fmt.Printf("%s %s\n", statusIcon, joinParts(parts))
+		if milestone.StatusLocked {
+			parts = append(parts, "🔒")
+		}
 
-		// Show tasks for this milestone
-		tasks := roadmap.GetTasksByParentID(milestone.ID)
-		sort.Slice(tasks, func(i, j int) bool {
-			return tasks[i].ID < tasks[j].ID
-		})
-
-		for _, task := range tasks {
-			taskIcon := "📝"
-			if task.Status == "done" {
-				taskIcon = "✅"
-			} else if task.Status == "in-progress" {
-				taskIcon = "🔄"
-			} else if task.Status == "blocked" {
-				taskIcon = "🚫"
-			}
+		if note := assigneeNote(&milestone.Details); note != "" {
+			parts = append(parts, note)
+		}
+
+		if note := labelNote(&milestone.Details); note != "" {
+			parts = append(parts, note)
+		}
+
+		if note := effortNote(efforts[milestone.ID]); note != "" {
+			parts = append(parts, note)
+		}
+
+		if note := scheduleNote(&milestone.Details, milestone.Status); note != "" {
+			parts = append(parts, note)
+		}
 
-			fmt.Printf("   └─ %s %s - %s [%s]\n", 
-				taskIcon,
-				color.YellowString(task.ID), 
-				task.Title, 
-				colorizeStatus(task.Status))
+		if blockers := core.UnfinishedDependencies(roadmap, milestone.ID); len(blockers) > 0 {
+			statusIcon = "⛔"
+			parts = append(parts, blockedByNote(blockers))
 		}
 
+		// Print milestone
+		fmt.Printf("%s %s\n", statusIcon, joinParts(parts))
+
+		// Show the full task tree for this milestone
+		printTaskTree(roadmap, efforts, visible, milestone.ID, "   ")
+
 		fmt.Println() // Empty line between milestones
 	}
 
 	return nil
 }
 
-// Helper functions
-func matchesMilestoneFilters(milestone types.Milestone, c *cli.Context) bool {
-	if id := c.String("id"); id != "" && milestone.ID != id {
-		return false
+// printTaskTree prints the tasks below parentID, recursing to any depth.
+// A visible set limits it to those tasks; nil shows them all.
+func printTaskTree(roadmap *types.Roadmap, efforts map[string]core.Effort, visible map[string]bool, parentID string, indent string) {
+	var tasks []types.Task
+	for _, task := range roadmap.GetTasksByParentID(parentID) {
+		if visible == nil || visible[task.ID] {
+			tasks = append(tasks, task)
+		}
 	}
-	if family := c.String("family"); family != "" && milestone.Family != family {
-		return false
+	sort.Slice(tasks, func(i, j int) bool {
+		return types.CompareIDs(tasks[i].ID, tasks[j].ID) < 0
+	})
+
+	for i, task := range tasks {
+		branch, continuation := "├─", "│  "
+		if i == len(tasks)-1 {
+			branch, continuation = "└─", "   "
+		}
+
+		icon, note := taskStatusIcon(task.Status), ""
+		if task.StatusLocked {
+			note += " 🔒"
+		}
+		if assignees := assigneeNote(&task.Details); assignees != "" {
+			note += " " + assignees
+		}
+		if labels := labelNote(&task.Details); labels != "" {
+			note += " " + labels
+		}
+		if effort := effortNote(efforts[task.ID]); effort != "" {
+			note += " " + effort
+		}
+		if schedule := scheduleNote(&task.Details, task.Status); schedule != "" {
+			note += " " + schedule
+		}
+		if blockers := core.UnfinishedDependencies(roadmap, task.ID); len(blockers) > 0 {
+			icon = "⛔"
+			note += " " + blockedByNote(blockers)
+		}
+
+		fmt.Printf("%s%s %s %s - %s [%s]%s\n",
+			indent,
+			branch,
+			icon,
+			color.YellowString(task.ID),
+			task.Title,
+			colorizeStatus(task.Status),
+			note)
+
+		printTaskTree(roadmap, efforts, visible, task.ID, indent+continuation)
+	}
+}
+
+// effortNote renders rolled-up effort as "3/8 pts", or "" when unestimated
+func effortNote(effort core.Effort) string {
+	if effort.Total == 0 {
+		return ""
+	}
+	return color.HiBlackString("%s/%s pts", formatPoints(effort.Completed), formatPoints(effort.Total))
+}
+
+// formatPoints prints points without trailing zeros (3, 2.5)
+func formatPoints(points float64) string {
+	return strconv.FormatFloat(points, 'f', -1, 64)
+}
+
+// blockedByNote describes the unfinished dependencies holding an item up
+func blockedByNote(blockers []string) string {
+	return color.RedString("blocked by " + strings.Join(blockers, ", "))
+}
+
+// dueSoonDays is how close an end date must be for show to flag it
+const dueSoonDays = 7
+
+// scheduleNote flags items that are overdue or due within dueSoonDays, and
+// shows the end date of anything else still open
+func scheduleNote(details *types.Details, status string) string {
+	days, ok := core.DaysLeft(details, status, time.Now())
+	if !ok {
+		return ""
 	}
-	if priority := c.String("priority"); priority != "" && milestone.Priority != priority {
+	switch {
+	case days < 0:
+		return color.New(color.FgRed, color.Bold).Sprintf("overdue %dd", -days)
+	case days == 0:
+		return color.YellowString("due today")
+	case days <= dueSoonDays:
+		return color.YellowString("due in %dd", days)
+	default:
+		return color.HiBlackString("due %s", details.End())
+	}
+}
+
+// taskStatusIcon returns the icon the status workflow gives a status
+func taskStatusIcon(status string) string {
+	if state := types.WorkflowFor(types.WorkflowStatus).State(status); state != nil && state.Icon != "" {
+		return state.Icon
+	}
+	return "📝"
+}
+
+// milestoneStatusIcon picks a milestone's icon: its status once work has
+// started, otherwise its cycle status, otherwise the plain milestone icon
+func milestoneStatusIcon(milestone *types.Milestone) string {
+	for _, check := range []struct {
+		field string
+		value string
+	}{
+		{types.WorkflowStatus, milestone.Status},
+		{types.WorkflowCycleStatus, milestone.CycleStatus},
+	} {
+		workflow := types.WorkflowFor(check.field)
+		if workflow == nil || check.value == workflow.Initial() {
+			continue
+		}
+		if state := workflow.State(check.value); state != nil && state.Icon != "" {
+			return state.Icon
+		}
+	}
+	return "📋"
+}
+
+// showFilter holds the filter flags of show, parsed once and applied the
+// same way by the tree, milestone and task views
+type showFilter struct {
+	c      *cli.Context
+	labels []core.LabelQuery
+	fields []fieldFilter
+}
+
+// fieldFilter is one --field key=value, with the value in stored form
+type fieldFilter struct {
+	key   string
+	value string
+}
+
+// newShowFilter parses the filter flags, rejecting a malformed --label or
+// --field before anything is shown. Values of typed custom fields are
+// compared in their stored form, so "--field review=friday" works.
+func newShowFilter(c *cli.Context) (*showFilter, error) {
+	filter := &showFilter{c: c}
+	for _, expr := range c.StringSlice("label") {
+		query, err := core.ParseLabelQuery(expr)
+		if err != nil {
+			return nil, err
+		}
+		filter.labels = append(filter.labels, query)
+	}
+	for _, assignment := range c.StringSlice("field") {
+		key, value, err := types.ParseFieldAssignment(assignment)
+		if err != nil {
+			return nil, err
+		}
+		if field := types.FindCustomField(key); field != nil {
+			if parsed, err := field.Parse(value, time.Now()); err == nil {
+				value = parsed
+			}
+		}
+		filter.fields = append(filter.fields, fieldFilter{key: key, value: value})
+	}
+	return filter, nil
+}
+
+// matchesMilestone applies every filter to a milestone
+func (f *showFilter) matchesMilestone(roadmap *types.Roadmap, milestone *types.Milestone) bool {
+	return f.inScope(milestone) &&
+		f.matchesItem(roadmap, milestone.ID, milestone.Status, milestone.Priority, &milestone.Details, milestone.Metadata)
+}
+
+// matchesTask applies every filter to a task; --family and --cycle-status
+// are checked against the milestone it sits under
+func (f *showFilter) matchesTask(roadmap *types.Roadmap, task *types.Task) bool {
+	return f.inScope(roadmap.MilestoneOf(task.ID)) &&
+		f.matchesItem(roadmap, task.ID, task.Status, task.Priority, &task.Details, task.Metadata)
+}
+
+// inScope applies the filters that describe a whole milestone
+func (f *showFilter) inScope(milestone *types.Milestone) bool {
+	var family, cycleStatus string
+	if milestone != nil {
+		family, cycleStatus = milestone.Family, milestone.CycleStatus
+	}
+	if want := f.c.String("family"); want != "" && family != want {
 		return false
 	}
-	if cycleStatus := c.String("cycle-status"); cycleStatus != "" && milestone.CycleStatus != cycleStatus {
+	if want := f.c.String("cycle-status"); want != "" && cycleStatus != want {
 		return false
 	}
 	return true
 }
 
-func matchesTaskFilters(task types.Task, c *cli.Context) bool {
-	if id := c.String("id"); id != "" && task.ID != id {
+// matchesItem applies the filters shared by milestones and tasks
+func (f *showFilter) matchesItem(roadmap *types.Roadmap, id, status, priority string, details *types.Details, metadata map[string]string) bool {
+	c := f.c
+	if want := c.String("id"); want != "" && !types.SameID(id, want) {
+		return false
+	}
+	if want := c.String("status"); want != "" && status != want {
+		return false
+	}
+	if want := c.String("priority"); want != "" && priority != want {
+		return false
+	}
+	if assignee := c.String("assignee"); assignee != "" && !details.IsAssignedTo(assigneeIdentities(assignee)...) {
+		return false
+	}
+	if release := c.String("release"); release != "" && roadmap.ReleaseOf(id) != release {
+		return false
+	}
+
+	now := time.Now()
+	if c.Bool("overdue") && !core.IsOverdue(details, status, now) {
 		return false
 	}
-	if status := c.String("status"); status != "" && task.Status != status {
+	if c.IsSet("due-soon") && !core.IsDueWithin(details, status, now, c.Int("due-soon")) {
 		return false
 	}
+
+	for _, query := range f.labels {
+		if !query.Matches(details) {
+			return false
+		}
+	}
+	for _, field := range f.fields {
+		if !strings.EqualFold(metadata[field.key], field.value) {
+			return false
+		}
+	}
 	return true
 }
 
-func displayMilestonesTable(milestones []types.Milestone) {
+// visible returns the items the tree view shows: every milestone and task
+// that matches, and the items a matching task sits under
+func (f *showFilter) visible(roadmap *types.Roadmap) map[string]bool {
+	visible := make(map[string]bool)
+	for i := range roadmap.Milestones {
+		if f.matchesMilestone(roadmap, &roadmap.Milestones[i]) {
+			visible[roadmap.Milestones[i].ID] = true
+		}
+	}
+	for i := range roadmap.Tasks {
+		task := &roadmap.Tasks[i]
+		if !f.matchesTask(roadmap, task) {
+			continue
+		}
+		visible[task.ID] = true
+		for parentID := task.ParentID; parentID != "" && !visible[parentID]; {
+			visible[parentID] = true
+			parent := roadmap.GetTaskByID(parentID)
+			if parent == nil {
+				break
+			}
+			parentID = parent.ParentID
+		}
+	}
+	return visible
+}
+
+// assigneeIdentities expands an assignee filter to every identity of the
+// matching roster entry
+func assigneeIdentities(assignee string) []string {
+	if person := projectConfig.FindPerson(assignee); person != nil {
+		return person.Identities()
+	}
+	return []string{assignee}
+}
+
+// fieldColumns renders the --columns custom fields as "key=value" cells
+func fieldColumns(metadata map[string]string, c *cli.Context) string {
+	var cells []string
+	for _, key := range strings.Split(c.String("columns"), ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		value := metadata[key]
+		if value == "" {
+			value = "-"
+		}
+		cells = append(cells, color.HiBlackString("%s=", key)+value)
+	}
+	return strings.Join(cells, " ")
+}
+
+// labelNote renders an item's labels as "#backend #api"
+func labelNote(details *types.Details) string {
+	if len(details.Labels) == 0 {
+		return ""
+	}
+	return color.MagentaString("#" + strings.Join(details.Labels, " #"))
+}
+
+// assigneeNote renders an item's assignees as "@alice @bob"
+func assigneeNote(details *types.Details) string {
+	if len(details.Assignees) == 0 {
+		return ""
+	}
+	return color.BlueString("@" + strings.Join(details.Assignees, " @"))
+}
+
+func displayMilestonesTable(roadmap *types.Roadmap, milestones []types.Milestone, c *cli.Context) {
 	for _, m := range milestones {
 		parts := []string{
 			color.CyanString(m.ID),
 			m.Title,
 		}
 		if m.Family != "" {
-			parts = append(parts, color.MagentaString("["+m.Family+"]"))
+			parts = append(parts, familyTag(roadmap, m.Family))
 		}
 		if m.Priority != "" {
 			parts = append(parts, colorizeStatus(m.Priority))
@@ -291,18 +649,57 @@ func displayMilestonesTable(milestones []types.Milestone) {
 		if m.CycleStatus != "" {
 			parts = append(parts, colorizeStatus(m.CycleStatus))
 		}
-		fmt.Printf("📋 %s\n", joinParts(parts))
+		if note := labelNote(&m.Details); note != "" {
+			parts = append(parts, note)
+		}
+		if columns := fieldColumns(m.Metadata, c); columns != "" {
+			parts = append(parts, columns)
+		}
+		if note := scheduleNote(&m.Details, m.Status); note != "" {
+			parts = append(parts, note)
+		}
+		icon := milestoneStatusIcon(&m)
+		if blockers := core.UnfinishedDependencies(roadmap, m.ID); len(blockers) > 0 {
+			icon = "⛔"
+			parts = append(parts, blockedByNote(blockers))
+		}
+		fmt.Printf("%s %s\n", icon, joinParts(parts))
 	}
 }
 
-func displayTasksTable(tasks []types.Task) {
+func displayTasksTable(roadmap *types.Roadmap, tasks []types.Task, c *cli.Context) {
 	for _, task := range tasks {
-		fmt.Printf("📝 %s - %s [%s] (parent: %s)\n", 
+		icon, note := taskStatusIcon(task.Status), ""
+		if labels := labelNote(&task.Details); labels != "" {
+			note += " " + labels
+		}
+		if columns := fieldColumns(task.Metadata, c); columns != "" {
+			note += " " + columns
+		}
+		if schedule := scheduleNote(&task.Details, task.Status); schedule != "" {
+			note += " " + schedule
+		}
+		if blockers := core.UnfinishedDependencies(roadmap, task.ID); len(blockers) > 0 {
+			icon = "⛔"
+			note += " " + blockedByNote(blockers)
+		}
+		fmt.Printf("%s %s - %s [%s] (parent: %s)%s\n", 
+			icon,
 			color.YellowString(task.ID), 
 			task.Title, 
 			colorizeStatus(task.Status),
-			color.CyanString(task.ParentID))
+			color.CyanString(task.ParentID),
+			note)
+	}
+}
+
+// familyTag renders "[D]" in the family's registered colour
+func familyTag(roadmap *types.Roadmap, code string) string {
+	family := roadmap.GetFamily(code)
+	if family == nil {
+		return color.MagentaString("[" + code + "]")
 	}
+	return "[" + colorizeFamily(family) + "]"
 }
 
 func joinParts(parts []string) string {
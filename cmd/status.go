@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/thusai/spiral/core"
+	"github.com/thusai/spiral/types"
+	"github.com/urfave/cli/v2"
+)
+
+// StatusCommand returns the status subcommand
+func StatusCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "status",
+		Usage:     "Show or change an item's status along the workflow",
+		ArgsUsage: "<id> [<status>]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "cycle", Usage: "Change the milestone's cycle status instead"},
+			&cli.BoolFlag{Name: "lock", Usage: "Keep this status instead of rolling it up from children"},
+			&cli.BoolFlag{Name: "unlock", Usage: "Let the status roll up from children again"},
+		},
+		Action: changeStatus,
+	}
+}
+
+func changeStatus(c *cli.Context) error {
+	if c.NArg() < 1 || c.NArg() > 2 {
+		return fmt.Errorf("usage: spiral status [--cycle] [--lock|--unlock] <id> [<status>]")
+	}
+	id := types.NormalizeID(c.Args().Get(0))
+	target := c.Args().Get(1)
+
+	field := types.WorkflowStatus
+	if c.Bool("cycle") {
+		field = types.WorkflowCycleStatus
+	}
+	workflow := types.WorkflowFor(field)
+
+	roadmap, err := roadmapStore.Load()
+	if err != nil {
+		return err
+	}
+
+	var current string
+	if milestone := roadmap.GetMilestoneByID(id); milestone != nil {
+		current = milestone.Status
+		if field == types.WorkflowCycleStatus {
+			current = milestone.CycleStatus
+		}
+	} else if task := roadmap.GetTaskByID(id); task != nil {
+		if field == types.WorkflowCycleStatus {
+			return fmt.Errorf("%s is a task; only milestones have a cycle status", id)
+		}
+		current = task.Status
+	} else {
+		return fmt.Errorf("item %s not found", id)
+	}
+
+	// Without a target just describe where the item can go
+	if target == "" && !c.Bool("lock") && !c.Bool("unlock") {
+		fmt.Printf("%s %s - %s [%s]\n", stateIcon(workflow, current), color.CyanString(id), roadmap.TitleOf(id), colorizeStatus(current))
+		printTimestamps(roadmap.DetailsOf(id))
+		next := workflow.Next(current)
+		if len(next) == 0 {
+			fmt.Println("   No transitions from here")
+			return nil
+		}
+		colored := make([]string, len(next))
+		for i, state := range next {
+			colored[i] = colorizeStatus(state)
+		}
+		fmt.Printf("   Can move to: %s\n", strings.Join(colored, ", "))
+		if milestone := roadmap.GetMilestoneByID(id); milestone != nil && len(milestone.Gates) > 0 && field == types.WorkflowStatus {
+			fmt.Printf("   Gates before %s:\n", colorizeStatus(types.GatedStatus()))
+			printGates(milestone)
+		}
+		return nil
+	}
+
+	updates := make(map[string]interface{})
+	if target != "" {
+		updates[field] = target
+	}
+	if c.Bool("lock") {
+		updates["status_locked"] = true
+	} else if c.Bool("unlock") {
+		updates["status_locked"] = false
+	}
+
+	// Run the gates a move to the gated status needs before taking the
+	// roadmap lock, so other commands don't wait on them
+	if milestone := roadmap.GetMilestoneByID(id); milestone != nil && field == types.WorkflowStatus &&
+		target != current && workflow.IsGated(target) && len(milestone.Gates) > 0 {
+		if err := core.CheckTransition(types.SchemaMilestones, field, current, target); err != nil {
+			return err
+		}
+		fmt.Printf("🚧 Running gates for %s...\n", color.CyanString(id))
+		defer core.PrepareGates(milestone)()
+	}
+
+	// Apply the change under the roadmap lock, reading the current state
+	// again in case the file changed since it was shown
+	var gateErr *core.GateError
+	roadmap, err = core.UpdateRoadmap(roadmapStore, func(roadmap *types.Roadmap) error {
+		gateErr = nil
+		update := core.UpdateTask
+		if milestone := roadmap.GetMilestoneByID(id); milestone != nil {
+			update = core.UpdateMilestone
+			current = milestone.Status
+			if field == types.WorkflowCycleStatus {
+				current = milestone.CycleStatus
+			}
+		} else if task := roadmap.GetTaskByID(id); task != nil {
+			current = task.Status
+		}
+
+		// Keep the gate results so the failures can be looked at later
+		if err := update(roadmap, id, updates); !errors.As(err, &gateErr) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if gateErr != nil {
+		fmt.Printf("🚧 %s did not pass its gates:\n", color.CyanString(id))
+		printGates(roadmap.GetMilestoneByID(id))
+		return gateErr
+	}
+
+	if target != "" {
+		fmt.Printf("%s %s: %s → %s\n", stateIcon(workflow, target), color.CyanString(id), colorizeStatus(current), colorizeStatus(target))
+
+		// The rollup on save may have put a parent back in line with its children
+		if status, _ := roadmap.StatusOf(id); field == types.WorkflowStatus && status != target {
+			fmt.Printf("   Rolled up to %s from its children (use --lock to keep %s)\n", colorizeStatus(status), target)
+		}
+	}
+	if c.Bool("lock") {
+		fmt.Printf("🔒 %s status locked\n", id)
+	} else if c.Bool("unlock") {
+		fmt.Printf("🔓 %s status follows its children again\n", id)
+	}
+	return nil
+}
+
+// stateIcon returns a workflow state's icon, or a neutral one
+func stateIcon(workflow *types.Workflow, status string) string {
+	if state := workflow.State(status); state != nil && state.Icon != "" {
+		return state.Icon
+	}
+	return "📋"
+}
+
+// printTimestamps lists when an item was created, started and completed
+func printTimestamps(details *types.Details) {
+	for _, stamp := range []struct {
+		label string
+		at    *time.Time
+	}{
+		{"Created", details.CreatedAt},
+		{"Started", details.StartedAt},
+		{"Completed", details.CompletedAt},
+		{"Updated", details.UpdatedAt},
+	} {
+		if stamp.at != nil {
+			fmt.Printf("   %-10s %s\n", stamp.label+":", stamp.at.Local().Format("2006-01-02 15:04"))
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/thusai/spiral/core"
+	"github.com/urfave/cli/v2"
+)
+
+// ValidateCommand returns the validate subcommand
+func ValidateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "validate",
+		Usage:     "Check the roadmap against the project schema",
+		ArgsUsage: "[file]",
+		Action:    validateRoadmap,
+	}
+}
+
+func validateRoadmap(c *cli.Context) error {
+	store := roadmapStore
+	if c.Args().Present() {
+		store = core.NewYAMLStore(c.Args().First())
+	}
+	filePath := store.Path()
+
+	violations, err := core.ValidateStore(store)
+	if err != nil {
+		return err
+	}
+
+	if len(violations) == 0 {
+		fmt.Printf("✅ %s is valid\n", filePath)
+		return nil
+	}
+
+	fmt.Printf("❌ %s has %d problem(s):\n\n", filePath, len(violations))
+	for _, v := range violations {
+		file := filePath
+		if v.File != "" {
+			file = filepath.Join(filePath, v.File)
+		}
+		if v.Line > 0 {
+			file += ":" + color.YellowString("%d", v.Line)
+		}
+		fmt.Printf("   %s: %s\n", file, v.Message)
+	}
+
+	return fmt.Errorf("validation failed")
+}
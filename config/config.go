@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/thusai/spiral/types"
 )
@@ -13,6 +14,9 @@ const (
 	DefaultConfigDir = ".spiral"
 	ContextFile     = "context.json"
 	ConfigFile      = "config.json"
+
+	// MaxCommitHistory caps how many tagged commits the context remembers
+	MaxCommitHistory = 50
 )
 
 // LoadContext loads the current working context
@@ -44,6 +48,8 @@ func SaveContext(context types.Context) error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 	
+	context.LastUpdated = time.Now()
+
 	data, err := json.MarshalIndent(context, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal context: %w", err)
@@ -53,7 +59,20 @@ func SaveContext(context types.Context) error {
 	return atomicWriteFile(contextPath, data)
 }
 
+// RecordCommit appends a tagged commit message to the context's commit history
+func RecordCommit(message string) error {
+	context, err := LoadContext()
+	if err != nil {
+		return err
+	}
 
+	context.CommitHistory = append(context.CommitHistory, message)
+	if len(context.CommitHistory) > MaxCommitHistory {
+		context.CommitHistory = context.CommitHistory[len(context.CommitHistory)-MaxCommitHistory:]
+	}
+
+	return SaveContext(context)
+}
 
 // ClearContext clears the current working context
 func ClearContext() error {
@@ -69,6 +88,44 @@ func ClearContext() error {
 
 
 
+// LoadConfig loads the project configuration from the config directory
+func LoadConfig() (types.Config, error) {
+	configPath := filepath.Join(DefaultConfigDir, ConfigFile)
+
+	// Return empty config if file doesn't exist
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		return types.Config{}, nil
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return types.Config{}, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var cfg types.Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return types.Config{}, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// SaveConfig saves the project configuration
+func SaveConfig(cfg types.Config) error {
+	if err := EnsureConfigDirectory(); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	cfg.LastUpdated = time.Now()
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	return atomicWriteFile(filepath.Join(DefaultConfigDir, ConfigFile), data)
+}
+
 // EnsureConfigDirectory ensures the spiral config directory exists
 func EnsureConfigDirectory() error {
 	return os.MkdirAll(DefaultConfigDir, 0755)
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/thusai/spiral/types"
+)
+
+// HistoryFile is the append-only change log inside the config directory
+const HistoryFile = "history.jsonl"
+
+// AppendHistory appends changes to the history log, one JSON object per line
+func AppendHistory(changes []types.Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(DefaultConfigDir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	historyPath := filepath.Join(DefaultConfigDir, HistoryFile)
+	file, err := os.OpenFile(historyPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer file.Close()
+
+	// Write everything in one call so concurrent appends don't interleave
+	var data []byte
+	for _, change := range changes {
+		line, err := json.Marshal(change)
+		if err != nil {
+			return fmt.Errorf("failed to marshal history entry: %w", err)
+		}
+		data = append(data, line...)
+		data = append(data, '\n')
+	}
+
+	if _, err := file.Write(data); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	return nil
+}
+
+// LoadHistory reads every entry in the history log, oldest first
+func LoadHistory() ([]types.Change, error) {
+	historyPath := filepath.Join(DefaultConfigDir, HistoryFile)
+
+	file, err := os.Open(historyPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer file.Close()
+
+	var changes []types.Change
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var change types.Change
+		if err := json.Unmarshal(scanner.Bytes(), &change); err != nil {
+			return nil, fmt.Errorf("failed to parse history file line %d: %w", line, err)
+		}
+		changes = append(changes, change)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+	return changes, nil
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thusai/spiral/types"
+)
+
+// inTempDir runs the test from an empty directory, where the config
+// directory is created
+func inTempDir(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func TestAppendHistory(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	change := func(id, field, old, new string) types.Change {
+		return types.Change{At: at, Who: "dev@example.com", ItemID: id, Field: field, Old: old, New: new}
+	}
+
+	tests := []struct {
+		name    string
+		batches [][]types.Change
+		want    []string // "item field old→new" of every entry, oldest first
+	}{
+		{name: "nothing to record"},
+		{
+			name:    "one batch",
+			batches: [][]types.Change{{change("D1", "status", "planned", "in-progress"), change("D1", "title", "A", "B")}},
+			want:    []string{"D1 status planned→in-progress", "D1 title A→B"},
+		},
+		{
+			name: "appends across saves",
+			batches: [][]types.Change{
+				{change("D1", "status", "planned", "in-progress")},
+				{},
+				{change("D1.1", "status", "in-progress", "done")},
+			},
+			want: []string{"D1 status planned→in-progress", "D1.1 status in-progress→done"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inTempDir(t)
+
+			for _, batch := range tt.batches {
+				if err := AppendHistory(batch); err != nil {
+					t.Fatalf("AppendHistory: %v", err)
+				}
+			}
+
+			changes, err := LoadHistory()
+			if err != nil {
+				t.Fatalf("LoadHistory: %v", err)
+			}
+			var got []string
+			for _, c := range changes {
+				got = append(got, c.ItemID+" "+c.Field+" "+c.Old+"→"+c.New)
+				if !c.At.Equal(at) || c.Who != "dev@example.com" {
+					t.Errorf("entry %v lost its time or author", c)
+				}
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("history:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestLoadHistoryRejectsBadLines(t *testing.T) {
+	inTempDir(t)
+	if err := os.MkdirAll(DefaultConfigDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `{"id":"D1","field":"status"}` + "\n\nnot json\n"
+	if err := os.WriteFile(filepath.Join(DefaultConfigDir, HistoryFile), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadHistory()
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Fatalf("LoadHistory error = %v, want one naming line 3", err)
+	}
+}
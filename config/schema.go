@@ -0,0 +1,59 @@
+package config
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/thusai/spiral/types"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaFile is the per-project schema override inside the config directory
+const SchemaFile = "schema.yml"
+
+// defaultSchema is the schema shipped with spiral
+//
+//go:embed schema.yml
+var defaultSchema []byte
+
+// DefaultSchema returns the schema shipped with spiral
+func DefaultSchema() (*types.Schema, error) {
+	var schema types.Schema
+	if err := yaml.Unmarshal(defaultSchema, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse built-in schema: %w", err)
+	}
+	return &schema, nil
+}
+
+// LoadSchema returns the built-in schema with the project override applied.
+// Status fields take their allowed values from the active workflows.
+func LoadSchema() (*types.Schema, error) {
+	schema, err := DefaultSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	schemaPath := filepath.Join(DefaultConfigDir, SchemaFile)
+
+	// No override is fine, the built-in schema applies as-is
+	if _, err := os.Stat(schemaPath); os.IsNotExist(err) {
+		schema.ApplyWorkflows(types.Workflows)
+		return schema, nil
+	}
+
+	data, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema file: %w", err)
+	}
+
+	var override types.Schema
+	if err := yaml.Unmarshal(data, &override); err != nil {
+		return nil, fmt.Errorf("failed to parse schema file %s: %w", schemaPath, err)
+	}
+
+	schema.Merge(&override)
+	schema.ApplyWorkflows(types.Workflows)
+	return schema, nil
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/thusai/spiral/types"
+)
+
+// Workload summarises the open work assigned to one person
+type Workload struct {
+	Assignee        string
+	OpenItems       int
+	RemainingPoints float64
+}
+
+// ComputeWorkload counts open items and their estimates per assignee, busiest
+// first. Assignees matching a roster entry are grouped under its handle and
+// everyone on the roster is listed, even with nothing assigned.
+func ComputeWorkload(roadmap *types.Roadmap, roster []types.Person) []Workload {
+	loads := make(map[string]*Workload)
+	for _, person := range roster {
+		loads[person.Handle] = &Workload{Assignee: person.Handle}
+	}
+
+	count := func(details *types.Details, status string) {
+		if types.IsFinishedStatus(status) {
+			return
+		}
+		for _, assignee := range details.Assignees {
+			key := canonicalAssignee(assignee, roster)
+			load, ok := loads[key]
+			if !ok {
+				load = &Workload{Assignee: key}
+				loads[key] = load
+			}
+			load.OpenItems++
+			load.RemainingPoints += details.Estimate()
+		}
+	}
+
+	for i := range roadmap.Milestones {
+		count(&roadmap.Milestones[i].Details, roadmap.Milestones[i].Status)
+	}
+	for i := range roadmap.Tasks {
+		count(&roadmap.Tasks[i].Details, roadmap.Tasks[i].Status)
+	}
+
+	workloads := make([]Workload, 0, len(loads))
+	for _, load := range loads {
+		workloads = append(workloads, *load)
+	}
+	sort.Slice(workloads, func(i, j int) bool {
+		if workloads[i].OpenItems != workloads[j].OpenItems {
+			return workloads[i].OpenItems > workloads[j].OpenItems
+		}
+		return workloads[i].Assignee < workloads[j].Assignee
+	})
+
+	return workloads
+}
+
+// AssignedItems returns the IDs of milestones and tasks assigned to any of
+// the identities, leaving out finished items unless includeFinished is set
+func AssignedItems(roadmap *types.Roadmap, identities []string, includeFinished bool) []string {
+	var ids []string
+
+	for _, milestone := range roadmap.Milestones {
+		if milestone.IsAssignedTo(identities...) && (includeFinished || !types.IsFinishedStatus(milestone.Status)) {
+			ids = append(ids, milestone.ID)
+		}
+	}
+	for _, task := range roadmap.Tasks {
+		if task.IsAssignedTo(identities...) && (includeFinished || !types.IsFinishedStatus(task.Status)) {
+			ids = append(ids, task.ID)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return types.CompareIDs(ids[i], ids[j]) < 0
+	})
+	return ids
+}
+
+// canonicalAssignee maps an assignee to its roster handle when possible
+func canonicalAssignee(assignee string, roster []types.Person) string {
+	for _, person := range roster {
+		for _, identity := range person.Identities() {
+			if identity != "" && strings.EqualFold(identity, assignee) {
+				return person.Handle
+			}
+		}
+	}
+	return assignee
+}
@@ -0,0 +1,33 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thusai/spiral/types"
+)
+
+// AddComment appends a comment by Actor to an item
+func AddComment(roadmap *types.Roadmap, id, body string) (types.Comment, error) {
+	details := roadmap.DetailsOf(id)
+	if details == nil {
+		return types.Comment{}, fmt.Errorf("item %s not found", id)
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return types.Comment{}, fmt.Errorf("comment cannot be empty")
+	}
+
+	comment := types.Comment{Author: Actor, At: now(), Body: body}
+	details.Comments = append(details.Comments, comment)
+	recordChange(roadmap, id, "comments", "", firstLine(body), "added")
+	return comment, nil
+}
+
+// firstLine shortens a comment to its first line for the history log
+func firstLine(body string) string {
+	if i := strings.IndexByte(body, '\n'); i >= 0 {
+		return body[:i] + " …"
+	}
+	return body
+}
@@ -0,0 +1,276 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/thusai/spiral/types"
+)
+
+// StartCycle activates a cycle. A planned cycle of that name is started with
+// any dates or capacity given overriding its own; otherwise a new cycle is
+// created. Only one cycle may run at a time.
+func StartCycle(roadmap *types.Roadmap, name string, start, end types.Date, capacity float64) (*types.Cycle, error) {
+	if name == "" {
+		return nil, fmt.Errorf("cycle name cannot be empty")
+	}
+	if running := roadmap.ActiveCycle(); running != nil {
+		return nil, fmt.Errorf("cycle %s is still active; close it first", running.Name)
+	}
+	if capacity < 0 {
+		return nil, fmt.Errorf("capacity cannot be negative: %v", capacity)
+	}
+
+	cycle := roadmap.GetCycle(name)
+	if cycle == nil {
+		roadmap.Cycles = append(roadmap.Cycles, types.Cycle{Name: name, Status: types.CyclePlanned})
+		cycle = &roadmap.Cycles[len(roadmap.Cycles)-1]
+		recordChange(roadmap, name, "created", "", name, "cycle")
+	} else if cycle.Status == types.CycleClosed {
+		return nil, fmt.Errorf("cycle %s is already closed", name)
+	}
+
+	if start.IsSet() {
+		cycle.Start = start
+	}
+	if !cycle.Start.IsSet() {
+		cycle.Start = types.DateOf(now())
+	}
+	if end.IsSet() {
+		cycle.End = end
+	}
+	if capacity > 0 {
+		cycle.Capacity = capacity
+	}
+
+	cycle.Status = types.CycleActive
+	recordChange(roadmap, name, "status", types.CyclePlanned, types.CycleActive, "cycle")
+
+	// Planned milestones are now in the cycle
+	for _, id := range cycle.Items {
+		if err := setCycleStatus(roadmap, id, types.WorkflowFor(types.WorkflowCycleStatus).Working()); err != nil {
+			return nil, err
+		}
+	}
+	return cycle, nil
+}
+
+// PlanCycleItems adds items to a cycle. An empty name means the active cycle,
+// or the next planned one when nothing is running.
+func PlanCycleItems(roadmap *types.Roadmap, name string, ids []string) (*types.Cycle, error) {
+	cycle, err := openCycle(roadmap, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		if !roadmap.HasItem(id) {
+			return nil, fmt.Errorf("item %s not found", id)
+		}
+		if other := roadmap.CycleOf(id); other != nil {
+			if other.Name == cycle.Name {
+				continue
+			}
+			return nil, fmt.Errorf("%s is already planned into %s", id, other.Name)
+		}
+
+		cycle.Items = append(cycle.Items, id)
+		recordChange(roadmap, id, "cycle", "", cycle.Name, "")
+
+		if cycle.Status == types.CycleActive {
+			if err := setCycleStatus(roadmap, id, types.WorkflowFor(types.WorkflowCycleStatus).Working()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return cycle, nil
+}
+
+// UnplanCycleItems takes items back out of a cycle
+func UnplanCycleItems(roadmap *types.Roadmap, name string, ids []string) (*types.Cycle, error) {
+	cycle, err := openCycle(roadmap, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		if !cycle.HasItem(id) {
+			return nil, fmt.Errorf("%s is not planned into %s", id, cycle.Name)
+		}
+		for i, item := range cycle.Items {
+			if item == id {
+				cycle.Items = append(cycle.Items[:i], cycle.Items[i+1:]...)
+				break
+			}
+		}
+		recordChange(roadmap, id, "cycle", cycle.Name, "", "")
+
+		if err := setCycleStatus(roadmap, id, types.WorkflowFor(types.WorkflowCycleStatus).Initial()); err != nil {
+			return nil, err
+		}
+	}
+	return cycle, nil
+}
+
+// CloseCycle ends the active cycle and writes its summary. Unfinished items
+// are carried into the next cycle: the planned cycle called next, or the
+// first planned cycle, or a new one of the same length that follows on.
+func CloseCycle(roadmap *types.Roadmap, next string) (*types.Cycle, error) {
+	cycle := roadmap.ActiveCycle()
+	if cycle == nil {
+		return nil, fmt.Errorf("no active cycle")
+	}
+	closedName := cycle.Name
+
+	effort := CycleEffort(roadmap, cycle)
+	summary := &types.CycleSummary{
+		ClosedAt:        now(),
+		PlannedPoints:   effort.Total,
+		CompletedPoints: effort.Completed,
+	}
+
+	for _, id := range cycle.Items {
+		if status, _ := roadmap.StatusOf(id); types.IsFinishedStatus(status) {
+			summary.Finished = append(summary.Finished, id)
+		} else {
+			summary.CarriedOver = append(summary.CarriedOver, id)
+		}
+	}
+
+	cycle.Status = types.CycleClosed
+	recordChange(roadmap, closedName, "status", types.CycleActive, types.CycleClosed, "cycle")
+
+	if done := types.WorkflowFor(types.WorkflowCycleStatus).Completed(); done != "" {
+		for _, id := range summary.Finished {
+			if err := setCycleStatus(roadmap, id, done); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(summary.CarriedOver) > 0 {
+		target, err := followingCycle(roadmap, cycle, next)
+		if err != nil {
+			return nil, err
+		}
+		summary.CarriedTo = target.Name
+		target.Items = append(target.Items, summary.CarriedOver...)
+		for _, id := range summary.CarriedOver {
+			recordChange(roadmap, id, "cycle", closedName, target.Name, "carried over")
+			if err := setCycleStatus(roadmap, id, types.WorkflowFor(types.WorkflowCycleStatus).Initial()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// followingCycle may have grown the slice, so look the cycle up again
+	cycle = roadmap.GetCycle(closedName)
+	cycle.Summary = summary
+	return cycle, nil
+}
+
+// CycleEffort totals the effort planned into a cycle. Items whose ancestor
+// is also planned in are already counted through the ancestor's rollup.
+func CycleEffort(roadmap *types.Roadmap, cycle *types.Cycle) Effort {
+	efforts := RollupEffort(roadmap)
+
+	var total Effort
+	for _, id := range cycle.Items {
+		if !hasPlannedAncestor(roadmap, cycle, id) {
+			total.Add(efforts[id])
+		}
+	}
+	return total
+}
+
+// hasPlannedAncestor reports whether any parent of id is also in the cycle
+func hasPlannedAncestor(roadmap *types.Roadmap, cycle *types.Cycle, id string) bool {
+	seen := make(map[string]bool)
+	for task := roadmap.GetTaskByID(id); task != nil && !seen[task.ID]; task = roadmap.GetTaskByID(task.ParentID) {
+		seen[task.ID] = true
+		if cycle.HasItem(task.ParentID) {
+			return true
+		}
+	}
+	return false
+}
+
+// openCycle finds a planned or active cycle by name, defaulting to the
+// active cycle and then the next planned one
+func openCycle(roadmap *types.Roadmap, name string) (*types.Cycle, error) {
+	if name != "" {
+		cycle := roadmap.GetCycle(name)
+		if cycle == nil {
+			return nil, fmt.Errorf("cycle %s not found", name)
+		}
+		if cycle.Status == types.CycleClosed {
+			return nil, fmt.Errorf("cycle %s is closed", name)
+		}
+		return cycle, nil
+	}
+	if cycle := roadmap.ActiveCycle(); cycle != nil {
+		return cycle, nil
+	}
+	if cycle := roadmap.NextPlannedCycle(); cycle != nil {
+		return cycle, nil
+	}
+	return nil, fmt.Errorf("no active or planned cycle (start one with: spiral cycle start --name=...)")
+}
+
+// followingCycle returns the cycle unfinished work moves into, creating it
+// when needed
+func followingCycle(roadmap *types.Roadmap, closed *types.Cycle, name string) (*types.Cycle, error) {
+	if name != "" {
+		if cycle := roadmap.GetCycle(name); cycle != nil {
+			if cycle.Status != types.CyclePlanned {
+				return nil, fmt.Errorf("cycle %s is %s; carry work into a planned cycle", name, cycle.Status)
+			}
+			return cycle, nil
+		}
+	} else if cycle := roadmap.NextPlannedCycle(); cycle != nil {
+		return cycle, nil
+	} else {
+		name = nextCycleName(roadmap, closed.Name)
+	}
+
+	next := types.Cycle{Name: name, Status: types.CyclePlanned, Capacity: closed.Capacity}
+	if start, err := closed.Start.Time(); err == nil {
+		if end, err := closed.End.Time(); err == nil {
+			next.Start = types.DateOf(end.AddDate(0, 0, 1))
+			next.End = types.DateOf(end.Add(end.Sub(start)).AddDate(0, 0, 1))
+		}
+	}
+
+	roadmap.Cycles = append(roadmap.Cycles, next)
+	recordChange(roadmap, name, "created", "", name, "cycle")
+	return &roadmap.Cycles[len(roadmap.Cycles)-1], nil
+}
+
+var trailingNumber = regexp.MustCompile(`^(.*?)(\d+)$`)
+
+// nextCycleName increments a trailing number ("Sprint 12" → "Sprint 13"),
+// or appends one ("Alpha" → "Alpha 2"), skipping names already taken
+func nextCycleName(roadmap *types.Roadmap, name string) string {
+	prefix, n := name+" ", 1
+	if match := trailingNumber.FindStringSubmatch(name); match != nil {
+		prefix = match[1]
+		n, _ = strconv.Atoi(match[2])
+	}
+	for {
+		n++
+		if candidate := prefix + strconv.Itoa(n); roadmap.GetCycle(candidate) == nil {
+			return candidate
+		}
+	}
+}
+
+// setCycleStatus moves a milestone's cycle status along its workflow; tasks
+// have no cycle status and are left alone
+func setCycleStatus(roadmap *types.Roadmap, id, status string) error {
+	milestone := roadmap.GetMilestoneByID(id)
+	if milestone == nil || milestone.CycleStatus == status {
+		return nil
+	}
+	return UpdateMilestone(roadmap, id, map[string]interface{}{"cycle_status": status})
+}
@@ -0,0 +1,137 @@
+package core
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/thusai/spiral/types"
+)
+
+// cycleRoadmap has Sprint 1 running with a finished milestone, an
+// unfinished one and a task planned into it
+func cycleRoadmap(cycles ...types.Cycle) *types.Roadmap {
+	return &types.Roadmap{
+		Milestones: []types.Milestone{
+			{ID: "D1", Family: "D", Title: "Ingest", Status: "done", CycleStatus: "in-cycle",
+				Details: types.Details{Points: 3}},
+			{ID: "D2", Family: "D", Title: "Reporting", Status: "in-progress", CycleStatus: "in-cycle",
+				Details: types.Details{Points: 5}},
+			{ID: "E1", Family: "E", Title: "Undo", Status: "planned"},
+		},
+		Tasks: append(tasks("E1.1:planned"), types.Task{ID: "E1.2", ParentID: "E1", Status: "done"}),
+		Cycles: append([]types.Cycle{{
+			Name: "Sprint 1", Status: types.CycleActive, Start: "2024-06-03", End: "2024-06-16", Capacity: 8,
+			Items: []string{"D1", "D2", "E1.1", "E1.2"},
+		}}, cycles...),
+	}
+}
+
+func TestCloseCycle(t *testing.T) {
+	tests := []struct {
+		name      string
+		cycles    []types.Cycle // besides Sprint 1
+		next      string
+		carriedTo types.Cycle // the cycle unfinished work moved into
+		wantErr   string
+	}{
+		{
+			name: "new cycle following on",
+			carriedTo: types.Cycle{
+				Name: "Sprint 2", Status: types.CyclePlanned, Start: "2024-06-17", End: "2024-06-30", Capacity: 8,
+				Items: []string{"D2", "E1.1"},
+			},
+		},
+		{
+			name:   "first planned cycle",
+			cycles: []types.Cycle{{Name: "Hardening", Status: types.CyclePlanned, Items: []string{"E1"}}},
+			carriedTo: types.Cycle{
+				Name: "Hardening", Status: types.CyclePlanned, Items: []string{"E1", "D2", "E1.1"},
+			},
+		},
+		{
+			name:   "named cycle",
+			cycles: []types.Cycle{{Name: "Hardening", Status: types.CyclePlanned}, {Name: "Polish", Status: types.CyclePlanned}},
+			next:   "Polish",
+			carriedTo: types.Cycle{
+				Name: "Polish", Status: types.CyclePlanned, Items: []string{"D2", "E1.1"},
+			},
+		},
+		{
+			name:    "named cycle already closed",
+			cycles:  []types.Cycle{{Name: "Sprint 0", Status: types.CycleClosed}},
+			next:    "Sprint 0",
+			wantErr: "cycle Sprint 0 is closed; carry work into a planned cycle",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inTempDir(t)
+			roadmap := cycleRoadmap(tt.cycles...)
+
+			closed, err := CloseCycle(roadmap, tt.next)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("CloseCycle error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CloseCycle: %v", err)
+			}
+
+			if closed.Name != "Sprint 1" || closed.Status != types.CycleClosed {
+				t.Errorf("closed %s as %s, want Sprint 1 closed", closed.Name, closed.Status)
+			}
+			if want := []string{"D1", "D2", "E1.1", "E1.2"}; !reflect.DeepEqual(closed.Items, want) {
+				t.Errorf("closed cycle items = %v, want them kept as %v", closed.Items, want)
+			}
+			summary := closed.Summary
+			if summary == nil {
+				t.Fatal("no summary written")
+			}
+			if !reflect.DeepEqual(summary.Finished, []string{"D1", "E1.2"}) ||
+				!reflect.DeepEqual(summary.CarriedOver, []string{"D2", "E1.1"}) || summary.CarriedTo != tt.carriedTo.Name {
+				t.Errorf("summary finished %v, carried %v to %q; want [D1 E1.2], [D2 E1.1] to %q",
+					summary.Finished, summary.CarriedOver, summary.CarriedTo, tt.carriedTo.Name)
+			}
+			if summary.PlannedPoints != 8 || summary.CompletedPoints != 3 {
+				t.Errorf("summary points %g of %g, want 3 of 8", summary.CompletedPoints, summary.PlannedPoints)
+			}
+
+			next := roadmap.GetCycle(tt.carriedTo.Name)
+			if next == nil {
+				t.Fatalf("no cycle %s", tt.carriedTo.Name)
+			}
+			if !reflect.DeepEqual(*next, tt.carriedTo) {
+				t.Errorf("next cycle = %+v, want %+v", *next, tt.carriedTo)
+			}
+
+			// Done work leaves the cycle done; carried milestones are planned again
+			for id, want := range map[string]string{"D1": "done", "D2": "planned", "E1": ""} {
+				if got := roadmap.GetMilestoneByID(id).CycleStatus; got != want {
+					t.Errorf("%s cycle_status = %q, want %q", id, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCloseCycleNothingToCarry(t *testing.T) {
+	inTempDir(t)
+	roadmap := cycleRoadmap()
+	roadmap.Cycles[0].Items = []string{"D1", "E1.2"}
+
+	closed, err := CloseCycle(roadmap, "")
+	if err != nil {
+		t.Fatalf("CloseCycle: %v", err)
+	}
+	if closed.Summary.CarriedTo != "" || len(roadmap.Cycles) != 1 {
+		t.Errorf("carried to %q with %d cycle(s), want no next cycle", closed.Summary.CarriedTo, len(roadmap.Cycles))
+	}
+
+	if _, err := CloseCycle(roadmap, ""); err == nil || err.Error() != "no active cycle" {
+		t.Errorf("closing again: error = %v, want no active cycle", err)
+	}
+}
@@ -0,0 +1,33 @@
+package core
+
+import (
+	"time"
+
+	"github.com/thusai/spiral/types"
+)
+
+// DaysLeft returns the number of days until an unfinished item's end date.
+// It reports false for finished items and items without an end date.
+func DaysLeft(details *types.Details, status string, now time.Time) (int, bool) {
+	if types.IsFinishedStatus(status) || !details.End().IsSet() {
+		return 0, false
+	}
+	days, err := details.End().DaysFrom(now)
+	if err != nil {
+		return 0, false
+	}
+	return days, true
+}
+
+// IsOverdue reports whether an unfinished item is past its end date
+func IsOverdue(details *types.Details, status string, now time.Time) bool {
+	days, ok := DaysLeft(details, status, now)
+	return ok && days < 0
+}
+
+// IsDueWithin reports whether an unfinished item ends within the next days
+// days, counting today
+func IsDueWithin(details *types.Details, status string, now time.Time, days int) bool {
+	left, ok := DaysLeft(details, status, now)
+	return ok && left >= 0 && left <= days
+}
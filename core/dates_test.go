@@ -0,0 +1,48 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thusai/spiral/types"
+)
+
+func TestDueDates(t *testing.T) {
+	now := time.Date(2024, time.June, 12, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		details types.Details
+		status  string
+		left    int
+		ok      bool
+		overdue bool
+		dueSoon bool // within a week
+	}{
+		{name: "no end date", status: "planned"},
+		{name: "due today", details: types.Details{Due: "2024-06-12"}, status: "planned", ok: true, dueSoon: true},
+		{name: "due in a week", details: types.Details{Due: "2024-06-19"}, status: "in-progress", left: 7, ok: true, dueSoon: true},
+		{name: "due in eight days", details: types.Details{Due: "2024-06-20"}, status: "planned", left: 8, ok: true},
+		{name: "past due", details: types.Details{Due: "2024-06-11"}, status: "in-progress", left: -1, ok: true, overdue: true},
+		{name: "past target", details: types.Details{Target: "2024-06-01"}, status: "blocked", left: -11, ok: true, overdue: true},
+		{name: "due wins over target", details: types.Details{Due: "2024-06-14", Target: "2024-06-01"}, status: "planned", left: 2, ok: true, dueSoon: true},
+		{name: "done late", details: types.Details{Due: "2024-06-01"}, status: "done"},
+		{name: "cancelled late", details: types.Details{Due: "2024-06-01"}, status: "cancelled"},
+		{name: "unreadable date", details: types.Details{Due: "someday"}, status: "planned"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, ok := DaysLeft(&tt.details, tt.status, now)
+			if left != tt.left || ok != tt.ok {
+				t.Errorf("DaysLeft = %d, %v, want %d, %v", left, ok, tt.left, tt.ok)
+			}
+			if got := IsOverdue(&tt.details, tt.status, now); got != tt.overdue {
+				t.Errorf("IsOverdue = %v, want %v", got, tt.overdue)
+			}
+			if got := IsDueWithin(&tt.details, tt.status, now, 7); got != tt.dueSoon {
+				t.Errorf("IsDueWithin 7 days = %v, want %v", got, tt.dueSoon)
+			}
+		})
+	}
+}
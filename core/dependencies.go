@@ -0,0 +1,162 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/thusai/spiral/types"
+)
+
+// AddDependency records that id cannot finish before dependsOn does
+func AddDependency(roadmap *types.Roadmap, id string, dependsOn string) error {
+	details := roadmap.DetailsOf(id)
+	if details == nil {
+		return fmt.Errorf("item %s not found", id)
+	}
+	if !roadmap.HasItem(dependsOn) {
+		return fmt.Errorf("item %s not found", dependsOn)
+	}
+	if id == dependsOn {
+		return fmt.Errorf("%s cannot depend on itself", id)
+	}
+
+	for _, existing := range details.DependsOn {
+		if existing == dependsOn {
+			return fmt.Errorf("%s already depends on %s", id, dependsOn)
+		}
+	}
+
+	details.DependsOn = append(details.DependsOn, dependsOn)
+
+	// Refuse the edge if it closes a loop
+	if cycle := findCycleFrom(roadmap, id); cycle != nil {
+		details.DependsOn = details.DependsOn[:len(details.DependsOn)-1]
+		return fmt.Errorf("adding %s → %s would create a dependency cycle: %s",
+			id, dependsOn, strings.Join(cycle, " → "))
+	}
+
+	recordChange(roadmap, id, "depends_on", "", dependsOn, "added")
+	return nil
+}
+
+// RemoveDependency drops a dependency edge
+func RemoveDependency(roadmap *types.Roadmap, id string, dependsOn string) error {
+	details := roadmap.DetailsOf(id)
+	if details == nil {
+		return fmt.Errorf("item %s not found", id)
+	}
+
+	for i, existing := range details.DependsOn {
+		if existing == dependsOn {
+			details.DependsOn = append(details.DependsOn[:i], details.DependsOn[i+1:]...)
+			recordChange(roadmap, id, "depends_on", dependsOn, "", "removed")
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s does not depend on %s", id, dependsOn)
+}
+
+// UnfinishedDependencies returns the dependencies of id that aren't done yet
+func UnfinishedDependencies(roadmap *types.Roadmap, id string) []string {
+	details := roadmap.DetailsOf(id)
+	if details == nil {
+		return nil
+	}
+
+	var unfinished []string
+	for _, dep := range details.DependsOn {
+		status, ok := roadmap.StatusOf(dep)
+		if ok && !types.IsFinishedStatus(status) {
+			unfinished = append(unfinished, dep)
+		}
+	}
+	return unfinished
+}
+
+// Dependents returns the items that depend on id
+func Dependents(roadmap *types.Roadmap, id string) []string {
+	var dependents []string
+	for itemID, deps := range dependencyGraph(roadmap) {
+		for _, dep := range deps {
+			if dep == id {
+				dependents = append(dependents, itemID)
+				break
+			}
+		}
+	}
+
+	sort.Slice(dependents, func(i, j int) bool {
+		return types.CompareIDs(dependents[i], dependents[j]) < 0
+	})
+	return dependents
+}
+
+// FindDependencyCycles returns every distinct dependency cycle in the roadmap
+func FindDependencyCycles(roadmap *types.Roadmap) [][]string {
+	graph := dependencyGraph(roadmap)
+
+	ids := make([]string, 0, len(graph))
+	for id := range graph {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return types.CompareIDs(ids[i], ids[j]) < 0
+	})
+
+	var cycles [][]string
+	reported := make(map[string]bool)
+	for _, id := range ids {
+		cycle := walkForCycle(graph, id, id, []string{id}, make(map[string]bool))
+		if cycle == nil {
+			continue
+		}
+
+		// The same loop is found from each of its members; report it once
+		members := append([]string{}, cycle[:len(cycle)-1]...)
+		sort.Strings(members)
+		key := strings.Join(members, ",")
+		if !reported[key] {
+			reported[key] = true
+			cycles = append(cycles, cycle)
+		}
+	}
+
+	return cycles
+}
+
+// findCycleFrom returns a dependency loop through start, or nil
+func findCycleFrom(roadmap *types.Roadmap, start string) []string {
+	return walkForCycle(dependencyGraph(roadmap), start, start, []string{start}, make(map[string]bool))
+}
+
+// walkForCycle follows dependency edges depth-first looking for a path
+// back to start
+func walkForCycle(graph map[string][]string, start, current string, path []string, visited map[string]bool) []string {
+	visited[current] = true
+	for _, next := range graph[current] {
+		if next == start {
+			return append(append([]string{}, path...), start)
+		}
+		if visited[next] {
+			continue
+		}
+		if cycle := walkForCycle(graph, start, next, append(path, next), visited); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// dependencyGraph maps every item ID to the IDs it depends on
+func dependencyGraph(roadmap *types.Roadmap) map[string][]string {
+	graph := make(map[string][]string)
+	for _, milestone := range roadmap.Milestones {
+		graph[milestone.ID] = milestone.DependsOn
+	}
+	for _, task := range roadmap.Tasks {
+		graph[task.ID] = task.DependsOn
+	}
+	return graph
+}
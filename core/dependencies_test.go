@@ -0,0 +1,89 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thusai/spiral/types"
+)
+
+// dependencyRoadmap builds a roadmap of milestones with the given edges,
+// written "D1>D2" for D1 depending on D2
+func dependencyRoadmap(ids []string, edges []string) *types.Roadmap {
+	roadmap := &types.Roadmap{}
+	for _, id := range ids {
+		roadmap.Milestones = append(roadmap.Milestones, types.Milestone{ID: id, Family: "D", Status: "planned"})
+	}
+	for _, edge := range edges {
+		from, to, _ := strings.Cut(edge, ">")
+		details := roadmap.DetailsOf(from)
+		details.DependsOn = append(details.DependsOn, to)
+	}
+	return roadmap
+}
+
+func TestFindDependencyCycles(t *testing.T) {
+	ids := []string{"D1", "D2", "D3", "D4"}
+	tests := []struct {
+		name  string
+		edges []string
+		want  []string // each cycle joined with " → "
+	}{
+		{name: "none", edges: []string{"D1>D2", "D2>D3", "D1>D3"}},
+		{name: "self", edges: []string{"D1>D1"}, want: []string{"D1 → D1"}},
+		{name: "pair", edges: []string{"D1>D2", "D2>D1"}, want: []string{"D1 → D2 → D1"}},
+		{name: "long", edges: []string{"D1>D2", "D2>D3", "D3>D4", "D4>D2"}, want: []string{"D2 → D3 → D4 → D2"}},
+		{
+			name:  "two separate",
+			edges: []string{"D1>D2", "D2>D1", "D3>D4", "D4>D3"},
+			want:  []string{"D1 → D2 → D1", "D3 → D4 → D3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, cycle := range FindDependencyCycles(dependencyRoadmap(ids, tt.edges)) {
+				got = append(got, strings.Join(cycle, " → "))
+			}
+			if strings.Join(got, "; ") != strings.Join(tt.want, "; ") {
+				t.Errorf("FindDependencyCycles = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddDependency(t *testing.T) {
+	ids := []string{"D1", "D2", "D3"}
+	tests := []struct {
+		name      string
+		edges     []string
+		id, on    string
+		wantErr   string
+		wantEdges int
+	}{
+		{name: "new edge", edges: []string{"D1>D2"}, id: "D2", on: "D3", wantEdges: 1},
+		{name: "closes a loop", edges: []string{"D1>D2", "D2>D3"}, id: "D3", on: "D1", wantErr: "D3 → D1 → D2 → D3"},
+		{name: "itself", id: "D1", on: "D1", wantErr: "cannot depend on itself"},
+		{name: "duplicate", edges: []string{"D1>D2"}, id: "D1", on: "D2", wantErr: "already depends on", wantEdges: 1},
+		{name: "unknown item", id: "D1", on: "D9", wantErr: "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roadmap := dependencyRoadmap(ids, tt.edges)
+			err := AddDependency(roadmap, tt.id, tt.on)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("AddDependency: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("AddDependency error = %v, want %q", err, tt.wantErr)
+			}
+
+			// A refused edge is not left behind
+			if got := len(roadmap.DetailsOf(tt.id).DependsOn); got != tt.wantEdges {
+				t.Errorf("%s has %d dependencies, want %d", tt.id, got, tt.wantEdges)
+			}
+		})
+	}
+}
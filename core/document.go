@@ -0,0 +1,404 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/thusai/spiral/types"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultIndent matches yaml.Marshal, used for files spiral wrote itself
+const defaultIndent = 4
+
+// marshalRoadmap renders a roadmap as YAML. A roadmap loaded from a file is
+// patched into that file's document instead of being written from scratch,
+// so comments, key order, quoting, blank lines and indentation survive and
+// a one-field change is a one-line diff.
+func marshalRoadmap(roadmap *types.Roadmap) ([]byte, error) {
+	return marshalDocument(roadmap, roadmap.Source)
+}
+
+// marshalDocument renders value as YAML, patched into source when there is
+// one
+func marshalDocument(value interface{}, source []byte) ([]byte, error) {
+	var document yaml.Node
+	if len(source) > 0 {
+		if err := yaml.Unmarshal(source, &document); err != nil {
+			document = yaml.Node{}
+		}
+	}
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal roadmap to YAML: %w", err)
+		}
+		return data, nil
+	}
+
+	var fresh yaml.Node
+	if err := fresh.Encode(value); err != nil {
+		return nil, fmt.Errorf("failed to marshal roadmap to YAML: %w", err)
+	}
+
+	layout := captureLayout(source, document.Content[0])
+	newPatcher(document.Content[0]).patchNode(document.Content[0], &fresh)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(layout.indent)
+	if err := encoder.Encode(&document); err != nil {
+		return nil, fmt.Errorf("failed to marshal roadmap to YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal roadmap to YAML: %w", err)
+	}
+
+	return layout.apply(buf.Bytes(), document.Content[0]), nil
+}
+
+// patcher merges a freshly encoded roadmap into the parsed document
+type patcher struct {
+	used  map[*yaml.Node]bool
+	items map[string][]*yaml.Node // keyed items anywhere in the document
+}
+
+// newPatcher indexes the items of a document, so that an item moved from
+// one section to another keeps its formatting
+func newPatcher(root *yaml.Node) *patcher {
+	p := &patcher{used: make(map[*yaml.Node]bool), items: make(map[string][]*yaml.Node)}
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		for _, child := range node.Content {
+			if node.Kind == yaml.SequenceNode {
+				if key := itemKey(child); key != nil {
+					p.items[key.Value] = append(p.items[key.Value], child)
+				}
+			}
+			walk(child)
+		}
+	}
+	walk(root)
+	return p
+}
+
+// patchNode updates dst in place to hold the data in src. Nodes whose data
+// is unchanged are left exactly as parsed; mapping keys keep their order,
+// with new keys placed after the key they follow in src.
+func (p *patcher) patchNode(dst, src *yaml.Node) {
+	if dst.Kind != src.Kind {
+		head, line, foot := dst.HeadComment, dst.LineComment, dst.FootComment
+		*dst = *src
+		dst.HeadComment, dst.LineComment, dst.FootComment = head, line, foot
+		return
+	}
+
+	switch dst.Kind {
+	case yaml.ScalarNode:
+		if sameScalar(dst, src) {
+			return
+		}
+		// Block styles only suit multi-line text, so follow the new value's
+		// style when either side needs one; otherwise keep the quoting
+		blockStyles := yaml.LiteralStyle | yaml.FoldedStyle
+		if dst.Style&blockStyles != 0 || src.Style&blockStyles != 0 {
+			dst.Style = src.Style
+		}
+		dst.Value, dst.Tag = src.Value, src.Tag
+
+	case yaml.MappingNode, yaml.SequenceNode:
+		// An empty [] or {} was only flow style for lack of content
+		if len(dst.Content) == 0 && len(src.Content) > 0 {
+			dst.Style = src.Style
+		}
+		if dst.Kind == yaml.MappingNode {
+			dst.Content = p.patchMapping(dst.Content, src.Content)
+		} else {
+			dst.Content = p.patchSequence(dst.Content, src.Content)
+		}
+
+	default:
+		*dst = *src
+	}
+}
+
+// sameScalar reports whether two scalars hold the same value, so that a
+// hand-written 2.50 or 0x10 isn't rewritten as 2.5 or 16
+func sameScalar(a, b *yaml.Node) bool {
+	if a.Tag != b.Tag {
+		return false
+	}
+	if a.Value == b.Value {
+		return true
+	}
+	if a.Tag == "!!str" {
+		return false
+	}
+	var x, y interface{}
+	if a.Decode(&x) != nil || b.Decode(&y) != nil {
+		return false
+	}
+	return fmt.Sprint(x) == fmt.Sprint(y)
+}
+
+// patchMapping merges src key/value pairs into dst, keeping dst's order
+func (p *patcher) patchMapping(dst, src []*yaml.Node) []*yaml.Node {
+	wanted := make(map[string]*yaml.Node, len(src)/2)
+	for i := 0; i+1 < len(src); i += 2 {
+		wanted[src[i].Value] = src[i+1]
+	}
+
+	// Keep the existing keys that are still present, patching their values
+	var merged []*yaml.Node
+	for i := 0; i+1 < len(dst); i += 2 {
+		if value, ok := wanted[dst[i].Value]; ok {
+			p.patchNode(dst[i+1], value)
+			merged = append(merged, dst[i], dst[i+1])
+		}
+	}
+
+	// Slot new keys in after the key that precedes them in src
+	after := -1
+	for i := 0; i+1 < len(src); i += 2 {
+		if at := keyIndex(merged, src[i].Value); at >= 0 {
+			after = at
+			continue
+		}
+		at := 0
+		if after >= 0 {
+			at = after + 2
+		}
+		merged = append(merged[:at], append([]*yaml.Node{src[i], src[i+1]}, merged[at:]...)...)
+		after = at
+	}
+	return merged
+}
+
+// keyIndex returns the position of key in mapping content, or -1
+func keyIndex(content []*yaml.Node, key string) int {
+	for i := 0; i+1 < len(content); i += 2 {
+		if content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// patchSequence matches src items to dst items, by id, code or name where
+// items have one and by position otherwise, and patches the matches. Keyed
+// items not found in dst are looked for elsewhere in the document. The
+// result follows src's order.
+func (p *patcher) patchSequence(dst, src []*yaml.Node) []*yaml.Node {
+	claim := func(node *yaml.Node) *yaml.Node {
+		p.used[node] = true
+		return node
+	}
+	match := func(index int, item *yaml.Node) *yaml.Node {
+		key := itemKey(item)
+		if key == nil {
+			if index < len(dst) && !p.used[dst[index]] && itemKey(dst[index]) == nil {
+				return claim(dst[index])
+			}
+			return nil
+		}
+		for _, candidate := range dst {
+			if other := itemKey(candidate); !p.used[candidate] && other != nil && other.Value == key.Value {
+				return claim(candidate)
+			}
+		}
+		for _, candidate := range p.items[key.Value] {
+			if !p.used[candidate] {
+				return claim(candidate)
+			}
+		}
+		return nil
+	}
+
+	patched := make([]*yaml.Node, len(src))
+	for i, item := range src {
+		if existing := match(i, item); existing != nil {
+			p.patchNode(existing, item)
+			patched[i] = existing
+		} else {
+			patched[i] = item
+		}
+	}
+	return patched
+}
+
+// layout is the formatting of a hand-edited file that the YAML encoder
+// would otherwise normalise away
+type layout struct {
+	lines   []string
+	indent  int
+	blanks  map[*yaml.Node]int  // blank lines above a key or item
+	compact map[*yaml.Node]bool // sequences written flush with their key
+}
+
+// captureLayout records the indentation, blank lines and compact sequences
+// of the parsed source
+func captureLayout(source []byte, root *yaml.Node) *layout {
+	lines := strings.Split(string(source), "\n")
+	l := &layout{
+		lines:   lines,
+		blanks:  make(map[*yaml.Node]int),
+		compact: make(map[*yaml.Node]bool),
+	}
+
+	var walk func(node *yaml.Node)
+	mark := func(node *yaml.Node) {
+		if n := blankLinesAbove(lines, node.Line); n > 0 {
+			l.blanks[node] = n
+		}
+	}
+	walk = func(node *yaml.Node) {
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				mark(key)
+				if l.indent == 0 && value.Style&yaml.FlowStyle == 0 && value.Line > key.Line {
+					if offset := value.Column - key.Column; offset >= 2 && (value.Kind == yaml.MappingNode || value.Kind == yaml.SequenceNode) {
+						l.indent = offset
+					}
+				}
+				if value.Kind == yaml.SequenceNode && value.Style&yaml.FlowStyle == 0 && value.Line > key.Line && value.Column == key.Column {
+					l.compact[value] = true
+				}
+				walk(value)
+			}
+		case yaml.SequenceNode:
+			for _, item := range node.Content {
+				mark(item)
+				walk(item)
+			}
+		}
+	}
+	walk(root)
+
+	if l.indent == 0 || l.indent > 9 {
+		l.indent = defaultIndent
+	}
+	return l
+}
+
+// apply restores the captured layout on freshly encoded output. The output
+// is parsed again and walked alongside the patched tree it came from, so
+// every remembered node can be found in the new text.
+func (l *layout) apply(output []byte, patched *yaml.Node) []byte {
+	var document yaml.Node
+	if err := yaml.Unmarshal(output, &document); err != nil || len(document.Content) == 0 {
+		return output
+	}
+	lines := strings.Split(string(output), "\n")
+
+	blanksAt := make(map[int]int)     // output line -> blank lines wanted above it
+	dedent := make([]int, len(lines)) // spaces to remove from each line
+	original := make(map[int]string)  // output line -> the source line it came from
+
+	var walk func(before, after *yaml.Node, key *yaml.Node)
+	walk = func(before, after, key *yaml.Node) {
+		if before.Kind != after.Kind || len(before.Content) != len(after.Content) {
+			return
+		}
+		if before.Line > 0 && before.Line <= len(l.lines) && after.Line > 0 {
+			original[after.Line-1] = l.lines[before.Line-1]
+		}
+		if n, ok := l.blanks[before]; ok {
+			if start := commentStart(lines, after.Line); n > blanksAt[start] {
+				blanksAt[start] = n
+			}
+		}
+		if l.compact[before] && key != nil && after.Kind == yaml.SequenceNode {
+			shift := after.Column - key.Column
+			start := commentStart(lines, after.Line)
+			for i := start - 1; i < len(lines); i++ {
+				if i >= after.Line && strings.TrimSpace(lines[i]) != "" && indentOf(lines[i]) < after.Column-1 {
+					break
+				}
+				dedent[i] += shift
+			}
+		}
+		switch before.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(before.Content); i += 2 {
+				walk(before.Content[i], after.Content[i], nil)
+				walk(before.Content[i+1], after.Content[i+1], after.Content[i])
+			}
+		case yaml.SequenceNode:
+			for i := range before.Content {
+				walk(before.Content[i], after.Content[i], nil)
+			}
+		}
+	}
+	walk(patched, document.Content[0], nil)
+
+	var out strings.Builder
+	for i, line := range lines {
+		if want := blanksAt[i+1] - blankLinesAbove(lines, i+1); want > 0 && i > 0 {
+			out.WriteString(strings.Repeat("\n", want))
+		}
+		if shift := dedent[i]; shift > 0 && indentOf(line) >= shift {
+			line = line[shift:]
+		}
+		if source, ok := original[i]; ok && line != source && sameLine(line, source) {
+			line = source
+		}
+		out.WriteString(line)
+		if i < len(lines)-1 {
+			out.WriteString("\n")
+		}
+	}
+	return []byte(out.String())
+}
+
+// sameLine reports whether two lines say the same thing and differ only in
+// spacing, such as a comment aligned by hand
+func sameLine(a, b string) bool {
+	canonical := func(line string) string {
+		line = strings.TrimSpace(line)
+		for strings.HasPrefix(line, "- ") {
+			line = strings.TrimSpace(line[2:])
+		}
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte(line), &node); err != nil {
+			return ""
+		}
+		data, err := yaml.Marshal(&node)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+	if indentOf(a) != indentOf(b) {
+		return false
+	}
+	canonicalA := canonical(a)
+	return canonicalA != "" && canonicalA == canonical(b)
+}
+
+// commentStart returns the first line of the comment block directly above
+// line (1-based), or line itself when there is none
+func commentStart(lines []string, line int) int {
+	start := line
+	for start > 1 && strings.HasPrefix(strings.TrimSpace(lines[start-2]), "#") {
+		start--
+	}
+	return start
+}
+
+// blankLinesAbove counts the blank lines above line (1-based) and the
+// comment block attached to it
+func blankLinesAbove(lines []string, line int) int {
+	count := 0
+	for i := commentStart(lines, line) - 2; i >= 0 && strings.TrimSpace(lines[i]) == ""; i-- {
+		count++
+	}
+	return count
+}
+
+// indentOf counts a line's leading spaces
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/thusai/spiral/types"
+	"gopkg.in/yaml.v3"
+)
+
+// documentSource is a hand-edited roadmap: comments, two-space indentation,
+// quoted strings, blank lines and its own key order
+const documentSource = `# Product roadmap
+schema_version: 2
+
+milestones:
+  # Data platform
+  - id: D1
+    title: "Ingest pipeline"   # keep short
+    family: D
+    status: planned
+    priority: high
+
+  - id: D2
+    family: D
+    title: 'Reporting'
+    status: planned
+
+tasks:
+  - id: D1.1
+    parent_id: D1
+    title: Parse events
+    status: in-progress
+`
+
+func TestMarshalDocumentKeepsLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(roadmap *types.Roadmap)
+		removed []string
+		added   []string
+	}{
+		{
+			name:   "no change",
+			change: func(roadmap *types.Roadmap) {},
+		},
+		{
+			name:    "one field",
+			change:  func(roadmap *types.Roadmap) { roadmap.Milestones[0].Status = "in-progress" },
+			removed: []string{"    status: planned"},
+			added:   []string{"    status: in-progress"},
+		},
+		{
+			name:    "commented field",
+			change:  func(roadmap *types.Roadmap) { roadmap.Milestones[0].Title = "Ingest" },
+			removed: []string{`    title: "Ingest pipeline"   # keep short`},
+			added:   []string{`    title: "Ingest" # keep short`},
+		},
+		{
+			name:   "new field",
+			change: func(roadmap *types.Roadmap) { roadmap.Tasks[0].Notes = "see RFC" },
+			added:  []string{"    notes: see RFC"},
+		},
+		{
+			name: "new item",
+			change: func(roadmap *types.Roadmap) {
+				roadmap.Tasks = append(roadmap.Tasks, types.Task{ID: "D1.2", ParentID: "D1", Title: "Store events", Status: "planned"})
+			},
+			added: []string{"  - id: D1.2", "    parent_id: D1", "    title: Store events", "    status: planned"},
+		},
+		{
+			name:    "removed item",
+			change:  func(roadmap *types.Roadmap) { roadmap.Milestones = roadmap.Milestones[:1] },
+			removed: []string{"  - id: D2", "    family: D", "    title: 'Reporting'", "    status: planned", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var roadmap types.Roadmap
+			if err := yaml.Unmarshal([]byte(documentSource), &roadmap); err != nil {
+				t.Fatal(err)
+			}
+			tt.change(&roadmap)
+
+			output, err := marshalDocument(&roadmap, []byte(documentSource))
+			if err != nil {
+				t.Fatalf("marshalDocument: %v", err)
+			}
+
+			removed, added := lineDiff(documentSource, string(output))
+			if strings.Join(removed, "\n") != strings.Join(tt.removed, "\n") ||
+				strings.Join(added, "\n") != strings.Join(tt.added, "\n") {
+				t.Errorf("diff removed %q, added %q\nwant removed %q, added %q\noutput:\n%s",
+					removed, added, tt.removed, tt.added, output)
+			}
+		})
+	}
+}
+
+func TestUpdateRoadmapDiff(t *testing.T) {
+	// documentSource with D1 rolled up from its in-progress task, so saving
+	// leaves it alone
+	source := strings.Replace(documentSource, "    status: planned", "    status: in-progress", 1)
+
+	tests := []struct {
+		name    string
+		id      string
+		status  string
+		changed []string // old and new line pairs, besides the timestamps stamped
+	}{
+		{
+			name:    "one status",
+			id:      "D2",
+			status:  "in-progress",
+			changed: []string{"'Reporting'\n    status: planned", "'Reporting'\n    status: in-progress"},
+		},
+		{
+			name:   "status that rolls up to the parent",
+			id:     "D1.1",
+			status: "done",
+			changed: []string{
+				"    status: in-progress", "    status: done",
+				"    status: in-progress", "    status: done",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inTempDir(t)
+			writeFile(t, RoadmapFile, source)
+
+			_, err := UpdateRoadmap(NewYAMLStore(RoadmapFile), func(roadmap *types.Roadmap) error {
+				if roadmap.GetTaskByID(tt.id) != nil {
+					return UpdateTask(roadmap, tt.id, map[string]interface{}{"status": tt.status})
+				}
+				return UpdateMilestone(roadmap, tt.id, map[string]interface{}{"status": tt.status})
+			})
+			if err != nil {
+				t.Fatalf("UpdateRoadmap: %v", err)
+			}
+			output, err := os.ReadFile(RoadmapFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := source
+			for i := 0; i < len(tt.changed); i += 2 {
+				want = strings.Replace(want, tt.changed[i], tt.changed[i+1], 1)
+			}
+			got := stampLines.ReplaceAllString(string(output), "")
+			if got != want {
+				removed, added := lineDiff(want, got)
+				t.Errorf("besides timestamps, removed %q and added %q\noutput:\n%s", removed, added, output)
+			}
+			if !stampLines.Match(output) {
+				t.Errorf("no timestamps stamped:\n%s", output)
+			}
+		})
+	}
+}
+
+// stampLines matches the timestamp fields a change through spiral sets
+var stampLines = regexp.MustCompile(`(?m)^    (updated|started|completed)_at: .*\n`)
+
+// lineDiff returns the lines between the common leading and trailing lines
+// of two texts, as removed from a and added in b
+func lineDiff(a, b string) ([]string, []string) {
+	before, after := strings.Split(a, "\n"), strings.Split(b, "\n")
+	for len(before) > 0 && len(after) > 0 && before[0] == after[0] {
+		before, after = before[1:], after[1:]
+	}
+	for len(before) > 0 && len(after) > 0 && before[len(before)-1] == after[len(after)-1] {
+		before, after = before[:len(before)-1], after[:len(after)-1]
+	}
+	return before, after
+}
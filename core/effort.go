@@ -0,0 +1,79 @@
+package core
+
+import (
+	"github.com/thusai/spiral/types"
+)
+
+// Effort is the estimated work for an item and everything below it, in points
+type Effort struct {
+	Total     float64
+	Completed float64
+}
+
+// Remaining returns the points not yet completed
+func (e Effort) Remaining() float64 {
+	return e.Total - e.Completed
+}
+
+// Add accumulates another effort into this one
+func (e *Effort) Add(other Effort) {
+	e.Total += other.Total
+	e.Completed += other.Completed
+}
+
+// RollupEffort computes the effort of every milestone and task. Items with
+// estimated children take the sum of their children; an item whose children
+// carry no estimates falls back to its own size or points. Work in a
+// dropped state counts for nothing, and work in any other terminal state
+// counts as completed.
+func RollupEffort(roadmap *types.Roadmap) map[string]Effort {
+	workflow := types.WorkflowFor(types.WorkflowStatus)
+
+	children := make(map[string][]*types.Task)
+	for i := range roadmap.Tasks {
+		task := &roadmap.Tasks[i]
+		children[task.ParentID] = append(children[task.ParentID], task)
+	}
+
+	efforts := make(map[string]Effort)
+
+	var rollup func(id string, details *types.Details, status string) Effort
+	rollup = func(id string, details *types.Details, status string) Effort {
+		if effort, done := efforts[id]; done {
+			return effort
+		}
+		// Mark as visited so malformed parent loops terminate
+		efforts[id] = Effort{}
+
+		var effort Effort
+		for _, child := range children[id] {
+			effort.Add(rollup(child.ID, &child.Details, child.Status))
+		}
+
+		if effort.Total == 0 {
+			effort.Total = details.Estimate()
+			effort.Completed = 0
+		}
+
+		switch {
+		case workflow.IsDropped(status):
+			effort = Effort{}
+		case workflow.IsCompleted(status):
+			effort.Completed = effort.Total
+		}
+
+		efforts[id] = effort
+		return effort
+	}
+
+	for i := range roadmap.Milestones {
+		milestone := &roadmap.Milestones[i]
+		rollup(milestone.ID, &milestone.Details, milestone.Status)
+	}
+	for i := range roadmap.Tasks {
+		task := &roadmap.Tasks[i]
+		rollup(task.ID, &task.Details, task.Status)
+	}
+
+	return efforts
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/thusai/spiral/types"
+)
+
+// AddFamily registers a new family. A roadmap that relied on the default
+// registry gets the defaults written out first so they stay registered.
+func AddFamily(roadmap *types.Roadmap, family types.Family) error {
+	if !types.IsValidFamily(family.Code) {
+		return fmt.Errorf("invalid family code: %s (letters only, e.g. D or OPS)", family.Code)
+	}
+	if family.Name == "" {
+		return fmt.Errorf("family name cannot be empty")
+	}
+	if err := CheckField(types.SchemaFamilies, "color", family.Color); err != nil {
+		return err
+	}
+	if err := CheckField(types.SchemaFamilies, "default_priority", family.DefaultPriority); err != nil {
+		return err
+	}
+
+	if len(roadmap.Families) == 0 {
+		roadmap.Families = append([]types.Family{}, types.DefaultFamilies...)
+	}
+
+	// Check for duplicate code
+	for _, existing := range roadmap.Families {
+		if existing.Code == family.Code {
+			return fmt.Errorf("family %s already exists", family.Code)
+		}
+	}
+
+	roadmap.Families = append(roadmap.Families, family)
+	recordChange(roadmap, family.Code, "created", "", family.Name, "")
+	return nil
+}
+
+// RenameFamily changes the display name of a registered family
+func RenameFamily(roadmap *types.Roadmap, code string, name string) error {
+	if name == "" {
+		return fmt.Errorf("family name cannot be empty")
+	}
+
+	if len(roadmap.Families) == 0 {
+		roadmap.Families = append([]types.Family{}, types.DefaultFamilies...)
+	}
+
+	for i := range roadmap.Families {
+		if roadmap.Families[i].Code == code {
+			recordChange(roadmap, code, "name", roadmap.Families[i].Name, name, "")
+			roadmap.Families[i].Name = name
+			return nil
+		}
+	}
+
+	return fmt.Errorf("family %s not found", code)
+}
@@ -0,0 +1,259 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thusai/spiral/types"
+)
+
+// GateTimeout bounds how long a single gate command may run
+var GateTimeout = 10 * time.Minute
+
+// gateWaitDelay is how long a timed out gate's output is still read for
+const gateWaitDelay = time.Second
+
+// gateOutputLines is how much of a command's output is kept in its result
+const gateOutputLines = 20
+
+// coveragePattern matches a percentage such as "coverage: 81.4% of statements"
+var coveragePattern = regexp.MustCompile(`(\d+(?:\.\d+)?)%`)
+
+// prepared holds command gate results run ahead of the roadmap lock by
+// PrepareGates, keyed by preparedKey
+var (
+	preparedMu sync.Mutex
+	prepared   = make(map[string]*types.GateResult)
+)
+
+// GateError refuses a change to done, carrying the gates that failed
+type GateError struct {
+	ID     string
+	Failed []types.Gate
+}
+
+func (e *GateError) Error() string {
+	problems := make([]string, len(e.Failed))
+	for i, gate := range e.Failed {
+		problems[i] = fmt.Sprintf("%s (%s)", gate.Name, gate.Problem())
+	}
+	return fmt.Sprintf("%s cannot be marked %s: %d gate(s) failed: %s",
+		e.ID, types.GatedStatus(), len(e.Failed), strings.Join(problems, ", "))
+}
+
+// AddGate adds a gate to a milestone
+func AddGate(roadmap *types.Roadmap, id string, gate types.Gate) error {
+	milestone := roadmap.GetMilestoneByID(id)
+	if milestone == nil {
+		return fmt.Errorf("milestone %s not found", id)
+	}
+	if err := gate.Validate(); err != nil {
+		return err
+	}
+	if milestone.Gate(gate.Name) != nil {
+		return fmt.Errorf("%s already has a gate named %q", id, gate.Name)
+	}
+
+	milestone.Gates = append(milestone.Gates, gate)
+	recordChange(roadmap, id, "gate", "", gate.Name, "added")
+	return nil
+}
+
+// RemoveGate removes a gate from a milestone
+func RemoveGate(roadmap *types.Roadmap, id, name string) error {
+	milestone := roadmap.GetMilestoneByID(id)
+	if milestone == nil {
+		return fmt.Errorf("milestone %s not found", id)
+	}
+	for i, gate := range milestone.Gates {
+		if gate.Name == name {
+			milestone.Gates = append(milestone.Gates[:i], milestone.Gates[i+1:]...)
+			recordChange(roadmap, id, "gate", name, "", "removed")
+			return nil
+		}
+	}
+	return fmt.Errorf("%s has no gate named %q", id, name)
+}
+
+// CheckGate ticks a checklist gate off, or clears it when checked is false
+func CheckGate(roadmap *types.Roadmap, id, name string, checked bool) error {
+	milestone := roadmap.GetMilestoneByID(id)
+	if milestone == nil {
+		return fmt.Errorf("milestone %s not found", id)
+	}
+	gate := milestone.Gate(name)
+	if gate == nil {
+		return fmt.Errorf("%s has no gate named %q", id, name)
+	}
+	if !gate.IsChecklist() {
+		return fmt.Errorf("gate %q runs a command; use spiral gate run to check it", name)
+	}
+
+	if gate.Checked != checked {
+		recordChange(roadmap, id, "gate "+name, checkState(gate.Checked), checkState(checked), "")
+		gate.Checked = checked
+	}
+	return nil
+}
+
+// PrepareGates runs a milestone's command gates ahead of time, so callers
+// can do the slow part before taking the roadmap lock instead of holding
+// it for up to GateTimeout per gate. The next RunGates in this process
+// records these results rather than running the commands again, as long as
+// the gate is unchanged. The returned function forgets them.
+func PrepareGates(milestone *types.Milestone) func() {
+	var keys []string
+	for _, gate := range milestone.Gates {
+		if gate.IsChecklist() {
+			continue
+		}
+		key := preparedKey(milestone.ID, gate)
+		result := runGate(gate)
+
+		preparedMu.Lock()
+		prepared[key] = result
+		preparedMu.Unlock()
+		keys = append(keys, key)
+	}
+
+	return func() {
+		preparedMu.Lock()
+		defer preparedMu.Unlock()
+		for _, key := range keys {
+			delete(prepared, key)
+		}
+	}
+}
+
+// preparedKey identifies a gate run by its milestone and definition
+func preparedKey(id string, gate types.Gate) string {
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%g", id, gate.Name, gate.Run, gate.Coverage)
+}
+
+// gateResult returns the prepared result for a gate, or runs it now
+func gateResult(id string, gate types.Gate) *types.GateResult {
+	preparedMu.Lock()
+	result, ok := prepared[preparedKey(id, gate)]
+	preparedMu.Unlock()
+	if !ok {
+		return runGate(gate)
+	}
+	copied := *result
+	return &copied
+}
+
+// RunGates runs every command gate of a milestone in the current directory,
+// recording the results on the milestone. It returns a *GateError listing
+// the gates that are not met, checklist items included.
+func RunGates(roadmap *types.Roadmap, id string) error {
+	milestone := roadmap.GetMilestoneByID(id)
+	if milestone == nil {
+		return fmt.Errorf("milestone %s not found", id)
+	}
+
+	var failed []types.Gate
+	for i := range milestone.Gates {
+		gate := &milestone.Gates[i]
+		if !gate.IsChecklist() {
+			previous := ""
+			if gate.Result != nil {
+				previous = gateState(gate.Result.Passed)
+			}
+			gate.Result = gateResult(id, *gate)
+			recordChange(roadmap, id, "gate "+gate.Name, previous, gateState(gate.Result.Passed), gate.Problem())
+		}
+		if !gate.Passed() {
+			failed = append(failed, *gate)
+		}
+	}
+
+	if len(failed) > 0 {
+		return &GateError{ID: id, Failed: failed}
+	}
+	return nil
+}
+
+// runGate executes one command gate through the shell
+func runGate(gate types.Gate) *types.GateResult {
+	ctx, cancel := context.WithTimeout(context.Background(), GateTimeout)
+	defer cancel()
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", gate.Run)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	// Don't wait on children of the shell that outlive it holding the output
+	cmd.WaitDelay = gateWaitDelay
+
+	result := &types.GateResult{At: now()}
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		switch {
+		case ctx.Err() == context.DeadlineExceeded:
+			fmt.Fprintf(&output, "\ntimed out after %s", GateTimeout)
+			result.ExitCode = -1
+		case errors.As(err, &exitErr):
+			result.ExitCode = exitErr.ExitCode()
+		default:
+			fmt.Fprintf(&output, "\n%v", err)
+			result.ExitCode = -1
+		}
+	}
+
+	result.Passed = result.ExitCode == 0
+	if gate.Coverage > 0 {
+		result.Coverage = parseCoverage(output.String())
+		result.Passed = result.Passed && result.Coverage != nil && *result.Coverage >= gate.Coverage
+	}
+	if !result.Passed {
+		result.Output = tailLines(output.String(), gateOutputLines)
+	}
+	return result
+}
+
+// parseCoverage returns the last percentage printed, which is where test
+// tools put the total
+func parseCoverage(output string) *float64 {
+	matches := coveragePattern.FindAllStringSubmatch(output, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	value, err := strconv.ParseFloat(matches[len(matches)-1][1], 64)
+	if err != nil {
+		return nil
+	}
+	return &value
+}
+
+// tailLines keeps the last n lines of output
+func tailLines(output string, n int) string {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// gateState names a gate outcome for the history log
+func gateState(passed bool) string {
+	if passed {
+		return "passed"
+	}
+	return "failed"
+}
+
+// checkState names a checklist gate's tick for the history log
+func checkState(checked bool) string {
+	if checked {
+		return "checked"
+	}
+	return "unchecked"
+}
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thusai/spiral/types"
+)
+
+func TestRunGates(t *testing.T) {
+	tests := []struct {
+		name     string
+		gate     types.Gate
+		passed   bool
+		exitCode int
+		problem  string
+	}{
+		{name: "command passes", gate: types.Gate{Name: "build", Run: "true"}, passed: true},
+		{name: "command fails", gate: types.Gate{Name: "test", Run: "echo FAIL; exit 3"}, exitCode: 3, problem: "exited with status 3"},
+		{name: "command not found", gate: types.Gate{Name: "lint", Run: "no-such-spiral-command"}, exitCode: 127, problem: "exited with status 127"},
+		{name: "coverage above threshold", gate: types.Gate{Name: "cover", Run: "echo 'coverage: 81.4% of statements'", Coverage: 80}, passed: true},
+		{name: "coverage at threshold", gate: types.Gate{Name: "cover", Run: "echo 'coverage: 80% of statements'", Coverage: 80}, passed: true},
+		{name: "coverage below threshold", gate: types.Gate{Name: "cover", Run: "echo 'coverage: 79.9% of statements'", Coverage: 80}, problem: "coverage 79.9% is below 80%"},
+		{name: "coverage missing", gate: types.Gate{Name: "cover", Run: "echo ok", Coverage: 80}, problem: "no coverage figure in the output (needs 80%)"},
+		{name: "coverage with failing command", gate: types.Gate{Name: "cover", Run: "echo 'coverage: 95%'; exit 1", Coverage: 80}, exitCode: 1, problem: "exited with status 1"},
+		{name: "checklist ticked", gate: types.Gate{Name: "docs", Checked: true}, passed: true},
+		{name: "checklist not ticked", gate: types.Gate{Name: "docs"}, problem: "not checked off"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inTempDir(t)
+			roadmap := &types.Roadmap{Milestones: []types.Milestone{{ID: "D1", Title: "Login", Gates: []types.Gate{tt.gate}}}}
+
+			err := RunGates(roadmap, "D1")
+			var gateErr *GateError
+			if tt.passed != (err == nil) || (err != nil && !errors.As(err, &gateErr)) {
+				t.Fatalf("RunGates error = %v, want passed %v", err, tt.passed)
+			}
+
+			gate := roadmap.Milestones[0].Gates[0]
+			if got := gate.Problem(); got != tt.problem {
+				t.Errorf("problem = %q, want %q", got, tt.problem)
+			}
+			if gate.IsChecklist() {
+				return
+			}
+			if gate.Result == nil {
+				t.Fatal("no result recorded")
+			}
+			if gate.Result.ExitCode != tt.exitCode {
+				t.Errorf("exit code = %d, want %d", gate.Result.ExitCode, tt.exitCode)
+			}
+			if !tt.passed && gate.Result.Output == "" {
+				t.Errorf("failed gate kept no output")
+			}
+		})
+	}
+}
+
+func TestRunGatesTimeout(t *testing.T) {
+	inTempDir(t)
+	GateTimeout = 100 * time.Millisecond
+	defer func() { GateTimeout = 10 * time.Minute }()
+
+	roadmap := &types.Roadmap{Milestones: []types.Milestone{{ID: "D1", Title: "Login", Gates: []types.Gate{{Name: "slow", Run: "sleep 5"}}}}}
+	var gateErr *GateError
+	if err := RunGates(roadmap, "D1"); !errors.As(err, &gateErr) || len(gateErr.Failed) != 1 {
+		t.Fatalf("RunGates error = %v, want the slow gate failed", err)
+	}
+	result := roadmap.Milestones[0].Gates[0].Result
+	if result.ExitCode != -1 || !strings.Contains(result.Output, "timed out") {
+		t.Errorf("result = exit %d, output %q, want a timeout", result.ExitCode, result.Output)
+	}
+}
+
+func TestParseCoverage(t *testing.T) {
+	tests := []struct {
+		output string
+		want   float64 // -1 for none
+	}{
+		{output: "ok  \tpkg\tcoverage: 81.4% of statements", want: 81.4},
+		{output: "coverage: 100%", want: 100},
+		{output: "pkg/a 50.0%\npkg/b 90.0%\ntotal: (statements) 72.5%\n", want: 72.5},
+		{output: "Lines: 7%", want: 7},
+		{output: "no coverage here", want: -1},
+		{output: "% only", want: -1},
+		{output: "", want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			got := parseCoverage(tt.output)
+			switch {
+			case tt.want < 0 && got != nil:
+				t.Errorf("parseCoverage = %g, want none", *got)
+			case tt.want >= 0 && (got == nil || *got != tt.want):
+				t.Errorf("parseCoverage = %v, want %g", got, tt.want)
+			}
+		})
+	}
+}
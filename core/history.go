@@ -0,0 +1,133 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/thusai/spiral/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Actor is recorded as the author of every change; cmd sets it to the git
+// user.email (or the login name) on startup
+var Actor string
+
+// derivedFields change as a side effect of other fields and aren't logged
+var derivedFields = map[string]bool{
+	"created_at":   true,
+	"updated_at":   true,
+	"started_at":   true,
+	"completed_at": true,
+}
+
+// recordChange queues a history entry on the roadmap; entries are written to
+// the history log when the roadmap is saved. Every core mutation of an item
+// goes through here, so it also bumps the item's updated_at.
+func recordChange(roadmap *types.Roadmap, id, field, oldValue, newValue, note string) {
+	at := now()
+	if details := roadmap.DetailsOf(id); details != nil {
+		details.UpdatedAt = &at
+	}
+	roadmap.Changes = append(roadmap.Changes, types.Change{
+		At:     at,
+		Who:    Actor,
+		ItemID: id,
+		Field:  field,
+		Old:    oldValue,
+		New:    newValue,
+		Note:   note,
+	})
+}
+
+// recordDiff queues an entry for every field that differs between two
+// versions of an item
+func recordDiff(roadmap *types.Roadmap, id string, before, after interface{}) {
+	oldFields, newFields := itemFields(before), itemFields(after)
+
+	names := make([]string, 0, len(oldFields)+len(newFields))
+	for name := range oldFields {
+		names = append(names, name)
+	}
+	for name := range newFields {
+		if _, ok := oldFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !derivedFields[name] && oldFields[name] != newFields[name] {
+			recordChange(roadmap, id, name, oldFields[name], newFields[name], "")
+		}
+	}
+}
+
+// itemFields flattens an item into its YAML field names and display values
+func itemFields(item interface{}) map[string]string {
+	data, err := yaml.Marshal(item)
+	if err != nil {
+		return nil
+	}
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+
+	fields := make(map[string]string, len(raw))
+	for name, value := range raw {
+		fields[name] = formatValue(value)
+	}
+	return fields
+}
+
+// formatValue renders a field value for the history log
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = formatValue(item)
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, key := range keys {
+			parts[i] = key + "=" + formatValue(v[key])
+		}
+		return strings.Join(parts, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// ItemHistory returns the entries for id, oldest first. With subtree set it
+// also includes entries for everything below id.
+func ItemHistory(changes []types.Change, id string, subtree bool) []types.Change {
+	var parent *types.ID
+	if subtree {
+		parent, _ = types.ParseID(id)
+	}
+
+	var matching []types.Change
+	for _, change := range changes {
+		if change.ItemID == id {
+			matching = append(matching, change)
+			continue
+		}
+		if parent == nil {
+			continue
+		}
+		if child, err := types.ParseID(change.ItemID); err == nil && child.IsDescendantOf(*parent) {
+			matching = append(matching, change)
+		}
+	}
+	return matching
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thusai/spiral/types"
+)
+
+func TestUpdateTaskRecordsHistory(t *testing.T) {
+	tests := []struct {
+		name    string
+		updates map[string]interface{}
+		want    []string // "field old→new" of each entry queued
+	}{
+		{name: "nothing changes", updates: map[string]interface{}{"title": "T"}},
+		{
+			name:    "status",
+			updates: map[string]interface{}{"status": "in-progress"},
+			want:    []string{"status planned→in-progress"},
+		},
+		{
+			name:    "several fields in name order",
+			updates: map[string]interface{}{"title": "U", "priority": "high"},
+			want:    []string{"priority →high", "title T→U"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Actor = "dev@example.com"
+			defer func() { Actor = "" }()
+			roadmap := &types.Roadmap{
+				Milestones: []types.Milestone{{ID: "D1", Family: "D", Title: "M", Status: "planned"}},
+				Tasks:      []types.Task{{ID: "D1.1", ParentID: "D1", Title: "T", Status: "planned"}},
+			}
+
+			if err := UpdateTask(roadmap, "D1.1", tt.updates); err != nil {
+				t.Fatalf("UpdateTask: %v", err)
+			}
+
+			var got []string
+			for _, change := range roadmap.Changes {
+				if change.ItemID != "D1.1" || change.Who != Actor || change.At.IsZero() {
+					t.Errorf("entry %+v is not attributed to D1.1 by %s", change, Actor)
+				}
+				got = append(got, change.Field+" "+change.Old+"→"+change.New)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("changes:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+
+			// Timestamps move with every recorded change but are not logged
+			if updated := roadmap.Tasks[0].UpdatedAt; (updated != nil) != (len(tt.want) > 0) {
+				t.Errorf("updated_at = %v with %d change(s)", updated, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestItemHistory(t *testing.T) {
+	changes := []types.Change{
+		{ItemID: "D1", Field: "status"},
+		{ItemID: "D1.1", Field: "status"},
+		{ItemID: "D1.1.2", Field: "title"},
+		{ItemID: "D12", Field: "status"},
+		{ItemID: "D2", Field: "status"},
+	}
+
+	tests := []struct {
+		id      string
+		subtree bool
+		want    string
+	}{
+		{id: "D1", want: "D1"},
+		{id: "D1", subtree: true, want: "D1 D1.1 D1.1.2"},
+		{id: "D1.1", subtree: true, want: "D1.1 D1.1.2"},
+		{id: "D3", subtree: true, want: ""},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, change := range ItemHistory(changes, tt.id, tt.subtree) {
+			got = append(got, change.ItemID)
+		}
+		if strings.Join(got, " ") != tt.want {
+			t.Errorf("ItemHistory(%s, %v) = %v, want %s", tt.id, tt.subtree, got, tt.want)
+		}
+	}
+}
@@ -25,95 +25,72 @@ func (g *IDGenerator) GenerateNextMilestoneID(family string) string {
 		if milestone.Family == family {
 			// Parse the milestone ID to extract the number
 			if parsedID, err := types.ParseID(milestone.ID); err == nil {
-				if parsedID.Family == family && parsedID.Milestone > maxMilestone {
-					maxMilestone = parsedID.Milestone
+				if parsedID.Family == family && parsedID.Milestone() > maxMilestone {
+					maxMilestone = parsedID.Milestone()
 				}
 			}
 		}
 	}
 
 	// Return the next milestone ID
-	return fmt.Sprintf("%s%d", family, maxMilestone+1)
+	id := types.ID{Family: family, Path: []int{maxMilestone + 1}}
+	return id.String()
 }
 
-// GenerateNextTaskID generates the next task ID for a milestone
-func (g *IDGenerator) GenerateNextTaskID(milestoneID string) (string, error) {
-	// Parse the milestone ID to extract family and milestone number
-	parsedMilestone, err := types.ParseID(milestoneID)
+// GenerateNextChildID generates the next ID directly below any parent,
+// milestone or task, up to the configured maximum depth
+func (g *IDGenerator) GenerateNextChildID(parentID string) (string, error) {
+	parsedParent, err := types.ParseID(parentID)
 	if err != nil {
-		return "", fmt.Errorf("invalid milestone ID: %w", err)
+		return "", fmt.Errorf("invalid parent ID: %w", err)
 	}
 
-	if parsedMilestone.Task != nil || parsedMilestone.Subtask != nil {
-		return "", fmt.Errorf("expected milestone ID, got: %s", milestoneID)
+	if parsedParent.Level()+1 > types.MaxDepth {
+		return "", fmt.Errorf("cannot create child of %s: maximum depth is %d levels below a milestone",
+			parentID, types.MaxDepth)
 	}
 
-	maxTask := 0
+	maxChild := 0
 
-	// Find the highest task number for this milestone
+	// Find the highest child number for this parent, comparing parsed IDs
+	// so children written with another separator or padding still count
 	for _, task := range g.roadmap.Tasks {
-		if task.ParentID == milestoneID {
-			// Parse the task ID to extract the task number
-			if parsedID, err := types.ParseID(task.ID); err == nil {
-				if parsedID.Family == parsedMilestone.Family &&
-					parsedID.Milestone == parsedMilestone.Milestone &&
-					parsedID.Task != nil && *parsedID.Task > maxTask {
-					maxTask = *parsedID.Task
-				}
+		if parsedID, err := types.ParseID(task.ID); err == nil {
+			if parsedID.IsChildOf(*parsedParent) && parsedID.Number() > maxChild {
+				maxChild = parsedID.Number()
 			}
 		}
 	}
 
-	// Generate next task ID
-	taskNum := maxTask + 1
-	id := types.ID{
-		Family:    parsedMilestone.Family,
-		Milestone: parsedMilestone.Milestone,
-		Task:      &taskNum,
+	return parsedParent.Child(maxChild + 1).String(), nil
+}
+
+// GenerateNextTaskID generates the next task ID for a milestone
+func (g *IDGenerator) GenerateNextTaskID(milestoneID string) (string, error) {
+	parsedMilestone, err := types.ParseID(milestoneID)
+	if err != nil {
+		return "", fmt.Errorf("invalid milestone ID: %w", err)
+	}
+
+	if parsedMilestone.Level() != 0 {
+		return "", fmt.Errorf("expected milestone ID, got: %s", milestoneID)
 	}
 
-	return id.String(), nil
+	return g.GenerateNextChildID(milestoneID)
 }
 
-// GenerateNextSubtaskID generates the next subtask ID for a task
+// GenerateNextSubtaskID generates the next subtask ID for a task at any depth
 func (g *IDGenerator) GenerateNextSubtaskID(taskID string) (string, error) {
-	// Parse the task ID to extract family, milestone, and task number
 	parsedTask, err := types.ParseID(taskID)
 	if err != nil {
 		return "", fmt.Errorf("invalid task ID: %w", err)
 	}
 
-	if parsedTask.Task == nil || parsedTask.Subtask != nil {
+	if parsedTask.Level() == 0 {
 		return "", fmt.Errorf("expected task ID, got: %s", taskID)
 	}
 
-	maxSubtask := 0
-
-	// Find the highest subtask number for this task
-	for _, task := range g.roadmap.Tasks {
-		if task.ParentID == taskID {
-			// Parse the subtask ID to extract the subtask number
-			if parsedID, err := types.ParseID(task.ID); err == nil {
-				if parsedID.Family == parsedTask.Family &&
-					parsedID.Milestone == parsedTask.Milestone &&
-					parsedID.Task != nil && *parsedID.Task == *parsedTask.Task &&
-					parsedID.Subtask != nil && *parsedID.Subtask > maxSubtask {
-					maxSubtask = *parsedID.Subtask
-				}
-			}
-		}
-	}
-
-	// Generate next subtask ID
-	subtaskNum := maxSubtask + 1
-	id := types.ID{
-		Family:    parsedTask.Family,
-		Milestone: parsedTask.Milestone,
-		Task:      parsedTask.Task,
-		Subtask:   &subtaskNum,
-	}
-
-	return id.String(), nil
+	return g.GenerateNextChildID(taskID)
 }
 
 // ValidateID checks if an ID is properly formatted and doesn't conflict
@@ -124,19 +101,23 @@ func (g *IDGenerator) ValidateID(idStr string) error {
 		return err
 	}
 
+	if parsedID.Level() > types.MaxDepth {
+		return fmt.Errorf("ID %s is nested deeper than the maximum depth of %d", idStr, types.MaxDepth)
+	}
+
 	// Check for conflicts based on the ID type
-	switch parsedID.Level() {
-	case 0: // Milestone
+	if parsedID.Level() == 0 {
 		for _, milestone := range g.roadmap.Milestones {
-			if milestone.ID == idStr {
+			if types.SameID(milestone.ID, idStr) {
 				return fmt.Errorf("milestone ID %s already exists", idStr)
 			}
 		}
-	case 1, 2: // Task or Subtask
-		for _, task := range g.roadmap.Tasks {
-			if task.ID == idStr {
-				return fmt.Errorf("task ID %s already exists", idStr)
-			}
+		return nil
+	}
+
+	for _, task := range g.roadmap.Tasks {
+		if types.SameID(task.ID, idStr) {
+			return fmt.Errorf("task ID %s already exists", idStr)
 		}
 	}
 
@@ -150,20 +131,7 @@ func (g *IDGenerator) SuggestID(family string, parentID string) (string, error)
 		return g.GenerateNextMilestoneID(family), nil
 	}
 
-	// Parse parent to determine what type of ID to generate
-	parsedParent, err := types.ParseID(parentID)
-	if err != nil {
-		return "", fmt.Errorf("invalid parent ID: %w", err)
-	}
-
-	switch parsedParent.Level() {
-	case 0: // Parent is milestone, generate task
-		return g.GenerateNextTaskID(parentID)
-	case 1: // Parent is task, generate subtask
-		return g.GenerateNextSubtaskID(parentID)
-	default:
-		return "", fmt.Errorf("cannot create child of subtask %s", parentID)
-	}
+	return g.GenerateNextChildID(parentID)
 }
 
 // GetFamilyFromContext extracts family from current context or suggests based on patterns
@@ -187,8 +155,8 @@ func (g *IDGenerator) GetFamilyFromContext(context *types.Context) string {
 		}
 	}
 
-	// Default to "D" if no context available
-	return "D"
+	// Default to the first registered family if no context available
+	return g.roadmap.FamilyRegistry()[0].Code
 }
 
 // ExtractFamilyFromID extracts the family from any ID string
@@ -202,6 +170,9 @@ func ExtractFamilyFromID(idStr string) (string, error) {
 
 // FormatIDForCommit formats an ID for use in git commit messages
 func FormatIDForCommit(idStr string) string {
+	if parsedID, err := types.ParseID(idStr); err == nil {
+		return parsedID.CommitTag()
+	}
 	return fmt.Sprintf("[%s]", idStr)
 }
 
@@ -234,12 +205,13 @@ func ParseCommitMessage(message string) (string, string, bool) {
 		remainingMessage = remainingMessage[1:]
 	}
 
-	// Validate the extracted ID
-	if _, err := types.ParseID(id); err != nil {
+	// Validate the extracted ID and return it in the project's grammar
+	parsedID, err := types.ParseID(id)
+	if err != nil {
 		return "", message, false
 	}
 
-	return id, remainingMessage, true
+	return parsedID.String(), remainingMessage, true
 }
 
 // Convenience functions for direct use without IDGenerator instance
@@ -247,21 +219,7 @@ func ParseCommitMessage(message string) (string, string, bool) {
 // GenerateNextID generates the next ID for a given parent (convenience function)
 func GenerateNextID(parentID string, roadmap *types.Roadmap) (string, error) {
 	generator := NewIDGenerator(roadmap)
-	
-	// Parse parent to determine what type of ID to generate
-	parsedParent, err := types.ParseID(parentID)
-	if err != nil {
-		return "", fmt.Errorf("invalid parent ID: %w", err)
-	}
-
-	switch parsedParent.Level() {
-	case 0: // Parent is milestone, generate task
-		return generator.GenerateNextTaskID(parentID)
-	case 1: // Parent is task, generate subtask
-		return generator.GenerateNextSubtaskID(parentID)
-	default:
-		return "", fmt.Errorf("cannot create child of subtask %s", parentID)
-	}
+	return generator.GenerateNextChildID(parentID)
 }
 
 // GenerateNextFamilyID generates the next milestone ID for a family (convenience function)
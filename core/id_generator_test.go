@@ -0,0 +1,60 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/thusai/spiral/types"
+)
+
+func TestGenerateNextChildID(t *testing.T) {
+	roadmap := &types.Roadmap{
+		Milestones: []types.Milestone{{ID: "D1", Family: "D"}},
+		Tasks: []types.Task{
+			{ID: "D1.1", ParentID: "D1"},
+			{ID: "D1.2", ParentID: "D1"},
+			{ID: "D1.2.1", ParentID: "D1.2"},
+			{ID: "D1.2.1.9", ParentID: "D1.2.1"},
+			{ID: "D1.2.1.9.1", ParentID: "D1.2.1.9"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		parentID string
+		maxDepth int
+		want     string
+		wantErr  bool
+	}{
+		{name: "task under milestone", parentID: "D1", want: "D1.3"},
+		{name: "first child", parentID: "D1.1", want: "D1.1.1"},
+		{name: "after existing children", parentID: "D1.2.1", want: "D1.2.1.10"},
+		{name: "deepest allowed level", parentID: "D1.2.1.9", want: "D1.2.1.9.2"},
+		{name: "below maximum depth", parentID: "D1.2.1.9.1", wantErr: true},
+		{name: "configured shallower depth", parentID: "D1.2", maxDepth: 2, want: "D1.2.2"},
+		{name: "past configured depth", parentID: "D1.2.1", maxDepth: 2, wantErr: true},
+		{name: "unparseable parent", parentID: "1.2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.maxDepth > 0 {
+				types.MaxDepth = tt.maxDepth
+				defer func() { types.MaxDepth = types.DefaultMaxDepth }()
+			}
+
+			got, err := NewIDGenerator(roadmap).GenerateNextChildID(tt.parentID)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("GenerateNextChildID(%s) = %s, want an error", tt.parentID, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GenerateNextChildID(%s): %v", tt.parentID, err)
+			}
+			if got != tt.want {
+				t.Errorf("GenerateNextChildID(%s) = %s, want %s", tt.parentID, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/thusai/spiral/types"
+)
+
+// JSONStore keeps the roadmap in a single JSON file, for tools that would
+// rather not read YAML
+type JSONStore struct {
+	path string
+}
+
+// NewJSONStore returns a store for a JSON file
+func NewJSONStore(path string) *JSONStore {
+	return &JSONStore{path: path}
+}
+
+// Path returns the JSON file
+func (s *JSONStore) Path() string {
+	return s.path
+}
+
+// Load reads the roadmap. A missing file is an empty roadmap, written on
+// the first save.
+func (s *JSONStore) Load() (*types.Roadmap, error) {
+	roadmap := &types.Roadmap{SchemaVersion: types.CurrentSchemaVersion}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read roadmap file: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, roadmap); err != nil {
+			return nil, fmt.Errorf("failed to parse roadmap JSON: %w", err)
+		}
+		if err := upgradeRoadmap(roadmap); err != nil {
+			return nil, err
+		}
+		roadmap.Source = data
+	}
+
+	if roadmap.Milestones == nil {
+		roadmap.Milestones = []types.Milestone{}
+	}
+	if roadmap.Tasks == nil {
+		roadmap.Tasks = []types.Task{}
+	}
+	return roadmap, nil
+}
+
+// Save writes the roadmap as indented JSON
+func (s *JSONStore) Save(roadmap *types.Roadmap) error {
+	return saveRoadmap(roadmap, s.path, func(roadmap *types.Roadmap) error {
+		data, err := json.MarshalIndent(roadmap, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal roadmap to JSON: %w", err)
+		}
+		data = append(data, '\n')
+
+		if err := checkUnchanged(roadmap, s.path); err != nil {
+			return err
+		}
+		if roadmap.Source == nil {
+			// Missing when loaded, so someone else created it since
+			if _, err := os.Stat(s.path); err == nil {
+				return &ConflictError{Path: s.path}
+			}
+		}
+		if err := atomicWriteFile(s.path, data); err != nil {
+			return err
+		}
+		roadmap.Source = data
+		return nil
+	})
+}
+
+// Watch polls the JSON file
+func (s *JSONStore) Watch(ctx context.Context, changed func()) error {
+	return watchFiles(ctx, changed, func() ([]string, error) {
+		return []string{s.path}, nil
+	})
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJSONStoreSaveConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		initial  string // file content before loading, "" for none
+		meantime string // written after loading, "" to leave it alone
+		conflict bool
+	}{
+		{name: "created", initial: "", meantime: "", conflict: false},
+		{name: "created by someone else", initial: "", meantime: `{"milestones":[]}`, conflict: true},
+		{name: "unchanged", initial: `{"milestones":[]}`, meantime: "", conflict: false},
+		{name: "changed by someone else", initial: `{"milestones":[]}`, meantime: `{"tasks":[]}`, conflict: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "roadmap.json")
+			if tt.initial != "" {
+				writeFile(t, path, tt.initial)
+			}
+
+			store := NewJSONStore(path)
+			roadmap, err := store.Load()
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if tt.meantime != "" {
+				writeFile(t, path, tt.meantime)
+			}
+
+			err = store.Save(roadmap)
+			var conflict *ConflictError
+			if got := errors.As(err, &conflict); got != tt.conflict {
+				t.Fatalf("Save error = %v, want conflict %v", err, tt.conflict)
+			}
+			if !tt.conflict && err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,285 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/thusai/spiral/types"
+)
+
+// LabelQuery is a parsed label expression such as "backend & !blocked"
+type LabelQuery interface {
+	Matches(details *types.Details) bool
+}
+
+type labelTerm string
+
+func (t labelTerm) Matches(details *types.Details) bool {
+	return details.HasLabel(string(t))
+}
+
+type labelNot struct{ query LabelQuery }
+
+func (n labelNot) Matches(details *types.Details) bool {
+	return !n.query.Matches(details)
+}
+
+type labelAnd []LabelQuery
+
+func (a labelAnd) Matches(details *types.Details) bool {
+	for _, query := range a {
+		if !query.Matches(details) {
+			return false
+		}
+	}
+	return true
+}
+
+type labelOr []LabelQuery
+
+func (o labelOr) Matches(details *types.Details) bool {
+	for _, query := range o {
+		if query.Matches(details) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseLabelQuery parses a label expression. Terms are combined with
+// "and" (also "&" or ","), "or" (also "|") and "not" (also "!" or a leading
+// "-"), with parentheses for grouping; "not" binds tightest, then "and".
+//
+//	backend
+//	backend,api            both labels
+//	frontend | design      either label
+//	security & !done       security but not done
+//	(ui or ux) and -spike
+func ParseLabelQuery(expr string) (LabelQuery, error) {
+	tokens, err := tokenizeLabelQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty label expression")
+	}
+
+	p := &labelParser{tokens: tokens}
+	query, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid label expression %q: %w", expr, err)
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("invalid label expression %q: unexpected %q", expr, p.tokens[p.pos])
+	}
+	return query, nil
+}
+
+// tokenizeLabelQuery splits an expression into labels, operators and
+// parentheses, mapping word operators to their symbols
+func tokenizeLabelQuery(expr string) ([]string, error) {
+	var tokens []string
+	var word strings.Builder
+
+	flush := func() {
+		if word.Len() == 0 {
+			return
+		}
+		switch w := word.String(); strings.ToLower(w) {
+		case "and":
+			tokens = append(tokens, "&")
+		case "or":
+			tokens = append(tokens, "|")
+		case "not":
+			tokens = append(tokens, "!")
+		default:
+			tokens = append(tokens, w)
+		}
+		word.Reset()
+	}
+
+	for _, r := range expr {
+		switch {
+		case unicode.IsSpace(r):
+			flush()
+		case r == '&' || r == ',' || r == '|' || r == '!' || r == '(' || r == ')':
+			flush()
+			if r == ',' {
+				r = '&'
+			}
+			tokens = append(tokens, string(r))
+		case r == '-' && word.Len() == 0:
+			tokens = append(tokens, "!")
+		default:
+			word.WriteRune(r)
+		}
+	}
+	flush()
+
+	for _, token := range tokens {
+		if len(token) > 1 || !strings.Contains("&|!()", token) {
+			if !types.IsValidLabel(token) {
+				return nil, fmt.Errorf("invalid label: %q", token)
+			}
+		}
+	}
+	return tokens, nil
+}
+
+type labelParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *labelParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *labelParser) parseOr() (LabelQuery, error) {
+	query, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	terms := labelOr{query}
+	for p.peek() == "|" {
+		p.pos++
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, next)
+	}
+	if len(terms) == 1 {
+		return query, nil
+	}
+	return terms, nil
+}
+
+func (p *labelParser) parseAnd() (LabelQuery, error) {
+	query, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	terms := labelAnd{query}
+	for p.peek() == "&" {
+		p.pos++
+		next, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, next)
+	}
+	if len(terms) == 1 {
+		return query, nil
+	}
+	return terms, nil
+}
+
+func (p *labelParser) parseNot() (LabelQuery, error) {
+	switch token := p.peek(); token {
+	case "!":
+		p.pos++
+		query, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return labelNot{query}, nil
+	case "(":
+		p.pos++
+		query, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing )")
+		}
+		p.pos++
+		return query, nil
+	case "", "&", "|", ")":
+		if token == "" {
+			return nil, fmt.Errorf("unexpected end of expression")
+		}
+		return nil, fmt.Errorf("unexpected %q", token)
+	default:
+		p.pos++
+		return labelTerm(token), nil
+	}
+}
+
+// LabelTargets returns id and, when recursive, every item below it
+func LabelTargets(roadmap *types.Roadmap, id string, recursive bool) ([]string, error) {
+	if !roadmap.HasItem(id) {
+		return nil, fmt.Errorf("item %s not found", id)
+	}
+	ids := []string{id}
+	if recursive {
+		ids = append(ids, roadmap.Descendants(id)...)
+	}
+	return ids, nil
+}
+
+// AddLabels adds labels to each item in ids, skipping labels an item already
+// has. It returns the number of items that changed.
+func AddLabels(roadmap *types.Roadmap, ids []string, labels []string) (int, error) {
+	for _, label := range labels {
+		if !types.IsValidLabel(label) {
+			return 0, fmt.Errorf("invalid label: %q (use letters, digits and - _ . : /)", label)
+		}
+	}
+
+	changed := 0
+	for _, id := range ids {
+		details := roadmap.DetailsOf(id)
+		if details == nil {
+			return changed, fmt.Errorf("item %s not found", id)
+		}
+		var added []string
+		for _, label := range labels {
+			if !details.HasLabel(label) {
+				details.Labels = append(details.Labels, label)
+				added = append(added, label)
+			}
+		}
+		if len(added) > 0 {
+			recordChange(roadmap, id, "labels", "", strings.Join(added, ", "), "added")
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// RemoveLabels removes labels from each item in ids. It returns the number of
+// items that changed.
+func RemoveLabels(roadmap *types.Roadmap, ids []string, labels []string) (int, error) {
+	changed := 0
+	for _, id := range ids {
+		details := roadmap.DetailsOf(id)
+		if details == nil {
+			return changed, fmt.Errorf("item %s not found", id)
+		}
+		var kept, removed []string
+		for _, existing := range details.Labels {
+			remove := false
+			for _, label := range labels {
+				if strings.EqualFold(existing, label) {
+					remove = true
+				}
+			}
+			if remove {
+				removed = append(removed, existing)
+			} else {
+				kept = append(kept, existing)
+			}
+		}
+		if len(removed) > 0 {
+			recordChange(roadmap, id, "labels", strings.Join(removed, ", "), "", "removed")
+			changed++
+		}
+		details.Labels = kept
+	}
+	return changed, nil
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thusai/spiral/types"
+)
+
+func TestParseLabelQuery(t *testing.T) {
+	tests := []struct {
+		expr    string
+		matches []string // label sets, comma separated, the query matches
+		misses  []string // label sets it doesn't
+		wantErr string
+	}{
+		{expr: "backend", matches: []string{"backend", "Backend,api"}, misses: []string{"", "api"}},
+		{expr: "backend,api", matches: []string{"api,backend"}, misses: []string{"backend", "api"}},
+		{expr: "backend and api", matches: []string{"api,backend"}, misses: []string{"backend"}},
+		{expr: "frontend | design", matches: []string{"frontend", "design"}, misses: []string{"", "backend"}},
+		{expr: "security & !done", matches: []string{"security"}, misses: []string{"security,done", "done"}},
+		{expr: "-spike", matches: []string{"", "api"}, misses: []string{"spike"}},
+		{expr: "not not spike", matches: []string{"spike"}, misses: []string{""}},
+		{expr: "ci-cd", matches: []string{"ci-cd"}, misses: []string{"ci", "cd"}},
+		{expr: "team:web/ui", matches: []string{"team:web/ui"}, misses: []string{"team:web"}},
+
+		// "and" binds tighter than "or", "not" tighter than both
+		{expr: "a | b & c", matches: []string{"a", "b,c"}, misses: []string{"b", "c"}},
+		{expr: "a & b | c", matches: []string{"a,b", "c"}, misses: []string{"a", "b"}},
+		{expr: "!a & b", matches: []string{"b"}, misses: []string{"a,b", ""}},
+		{expr: "!a | b", matches: []string{"", "a,b"}, misses: []string{"a"}},
+
+		// Parentheses override it
+		{expr: "(a | b) & c", matches: []string{"a,c", "b,c"}, misses: []string{"a", "c"}},
+		{expr: "!(a | b)", matches: []string{"", "c"}, misses: []string{"a", "b"}},
+		{expr: "(ui or ux) and -spike", matches: []string{"ui", "ux"}, misses: []string{"ui,spike", "spike"}},
+		{expr: "((a))", matches: []string{"a"}, misses: []string{"b"}},
+
+		{expr: "", wantErr: "empty label expression"},
+		{expr: "   ", wantErr: "empty label expression"},
+		{expr: "a &", wantErr: "unexpected end of expression"},
+		{expr: "& a", wantErr: `unexpected "&"`},
+		{expr: "a | | b", wantErr: `unexpected "|"`},
+		{expr: "!", wantErr: "unexpected end of expression"},
+		{expr: "a b", wantErr: `unexpected "b"`},
+		{expr: "(a", wantErr: "missing )"},
+		{expr: "a)", wantErr: `unexpected ")"`},
+		{expr: "()", wantErr: `unexpected ")"`},
+		{expr: "a$b", wantErr: `invalid label: "a$b"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			query, err := ParseLabelQuery(tt.expr)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseLabelQuery(%q) error = %v, want %q", tt.expr, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseLabelQuery(%q): %v", tt.expr, err)
+			}
+
+			for want, sets := range map[bool][]string{true: tt.matches, false: tt.misses} {
+				for _, set := range sets {
+					details := &types.Details{}
+					if set != "" {
+						details.Labels = strings.Split(set, ",")
+					}
+					if got := query.Matches(details); got != want {
+						t.Errorf("%q matches labels [%s] = %v, want %v", tt.expr, set, got, want)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,383 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/thusai/spiral/config"
+	"github.com/thusai/spiral/types"
+	"gopkg.in/yaml.v3"
+)
+
+// RoadmapFile is the single-file roadmap layout
+const RoadmapFile = "spiral.yml"
+
+// RoadmapDir holds a roadmap split across files: shared sections in
+// roadmap.yml and milestones with their tasks in <family>/<id>.yml
+var RoadmapDir = filepath.Join(config.DefaultConfigDir, "roadmap")
+
+// rootFileName is the file of a split roadmap holding the families, cycles
+// and releases, and any item without a family
+const rootFileName = "roadmap.yml"
+
+// splitFile is the content of one file of a split roadmap. Every section
+// is optional, so each file only shows what it holds.
+type splitFile struct {
+	SchemaVersion int               `yaml:"schema_version,omitempty"`
+	Families      []types.Family    `yaml:"families,omitempty"`
+	Milestones    []types.Milestone `yaml:"milestones,omitempty"`
+	Tasks         []types.Task      `yaml:"tasks,omitempty"`
+	Cycles        []types.Cycle     `yaml:"cycles,omitempty"`
+	Releases      []types.Release   `yaml:"releases,omitempty"`
+}
+
+// isEmpty reports whether the file holds nothing worth writing
+func (f *splitFile) isEmpty() bool {
+	return len(f.Families) == 0 && len(f.Milestones) == 0 && len(f.Tasks) == 0 &&
+		len(f.Cycles) == 0 && len(f.Releases) == 0
+}
+
+// RoadmapPath returns where the project's roadmap lives: the split
+// directory when there is one, otherwise spiral.yml
+func RoadmapPath() string {
+	if _, err := os.Stat(filepath.Join(RoadmapDir, rootFileName)); err == nil {
+		return RoadmapDir
+	}
+	return RoadmapFile
+}
+
+// IsRoadmapDir reports whether a roadmap path is a split directory
+func IsRoadmapDir(filePath string) bool {
+	info, err := os.Stat(filePath)
+	return err == nil && info.IsDir()
+}
+
+// roadmapFiles lists the files of a split roadmap relative to its
+// directory, roadmap.yml first and the family files in name order
+func roadmapFiles(dir string) ([]string, error) {
+	var files []string
+	if _, err := os.Stat(filepath.Join(dir, rootFileName)); err == nil {
+		files = append(files, rootFileName)
+	}
+
+	var familyFiles []string
+	for _, pattern := range []string{"*/*.yml", "*/*.yaml"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list roadmap files: %w", err)
+		}
+		for _, match := range matches {
+			rel, err := filepath.Rel(dir, match)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list roadmap files: %w", err)
+			}
+			familyFiles = append(familyFiles, filepath.ToSlash(rel))
+		}
+	}
+	sort.Strings(familyFiles)
+
+	return append(files, familyFiles...), nil
+}
+
+// loadRoadmapDir merges the files of a split roadmap into one roadmap,
+// remembering which file each item came from, and upgrades it the way a
+// single file is
+func loadRoadmapDir(dir string) (*types.Roadmap, error) {
+	files, err := roadmapFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	roadmap := &types.Roadmap{
+		Milestones: []types.Milestone{},
+		Tasks:      []types.Task{},
+		Files:      make(map[string]string),
+		Sources:    make(map[string][]byte),
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read roadmap file: %w", err)
+		}
+
+		var part types.Roadmap
+		if err := yaml.Unmarshal(data, &part); err != nil {
+			return nil, fmt.Errorf("failed to parse roadmap YAML in %s: %w", file, err)
+		}
+		roadmap.Sources[file] = data
+
+		if part.SchemaVersion > roadmap.SchemaVersion {
+			roadmap.SchemaVersion = part.SchemaVersion
+		}
+		roadmap.Families = append(roadmap.Families, part.Families...)
+		roadmap.Cycles = append(roadmap.Cycles, part.Cycles...)
+		roadmap.Releases = append(roadmap.Releases, part.Releases...)
+		for _, milestone := range part.Milestones {
+			roadmap.Files[milestone.ID] = file
+			roadmap.Milestones = append(roadmap.Milestones, milestone)
+		}
+		for _, task := range part.Tasks {
+			roadmap.Files[task.ID] = file
+			roadmap.Tasks = append(roadmap.Tasks, task)
+		}
+	}
+
+	// Older layouts are converted once every file is merged, since a
+	// file's in_cycle flags may refer to items kept in another
+	var migrated []string
+	for _, file := range files {
+		tasks := len(roadmap.Tasks)
+		notes, err := upgradeLegacy(roadmap.Sources[file], roadmap)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upgrade %s: %w", file, err)
+		}
+		for _, task := range roadmap.Tasks[tasks:] {
+			roadmap.Files[task.ID] = file
+		}
+		for _, note := range notes {
+			migrated = append(migrated, file+": "+note)
+		}
+	}
+
+	if err := upgradeLoaded(roadmap, dir, migrated); err != nil {
+		return nil, err
+	}
+	return roadmap, nil
+}
+
+// saveRoadmapDir writes each item of a split roadmap back to the file it
+// came from. Every file is checked for outside edits before any is written.
+func saveRoadmapDir(roadmap *types.Roadmap, dir string) error {
+	if roadmap.Files == nil {
+		roadmap.Files = make(map[string]string)
+	}
+	if roadmap.Sources == nil {
+		roadmap.Sources = make(map[string][]byte)
+	}
+
+	parts := map[string]*splitFile{
+		rootFileName: {
+			SchemaVersion: roadmap.SchemaVersion,
+			Families:      roadmap.Families,
+			Cycles:        roadmap.Cycles,
+			Releases:      roadmap.Releases,
+		},
+	}
+	part := func(file string) *splitFile {
+		if parts[file] == nil {
+			parts[file] = &splitFile{}
+		}
+		return parts[file]
+	}
+	for _, milestone := range roadmap.Milestones {
+		p := part(itemFile(roadmap, milestone.ID))
+		p.Milestones = append(p.Milestones, milestone)
+	}
+	for _, task := range roadmap.Tasks {
+		p := part(itemFile(roadmap, task.ID))
+		p.Tasks = append(p.Tasks, task)
+	}
+
+	// Files that lost all their items are removed
+	rendered := make(map[string][]byte)
+	for file, p := range parts {
+		if file != rootFileName && p.isEmpty() {
+			continue
+		}
+		data, err := marshalDocument(p, roadmap.Sources[file])
+		if err != nil {
+			return err
+		}
+		rendered[file] = data
+	}
+	return writeFiles(dir, roadmap.Sources, rendered)
+}
+
+// writeFiles writes the rendered files of a roadmap kept across a
+// directory, removing loaded files that are no longer rendered. Every file
+// is checked for outside edits before any is touched, and sources is
+// updated to what is on disk afterwards.
+func writeFiles(dir string, sources, rendered map[string][]byte) error {
+	files := make([]string, 0, len(rendered))
+	for file := range rendered {
+		files = append(files, file)
+	}
+	for file := range sources {
+		if _, ok := rendered[file]; !ok {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		filePath := filepath.Join(dir, filepath.FromSlash(file))
+		if source, loaded := sources[file]; loaded {
+			if err := checkFileUnchanged(filePath, source); err != nil {
+				return err
+			}
+		} else if _, err := os.Stat(filePath); err == nil {
+			// Created by someone else since the roadmap was loaded
+			return &ConflictError{Path: filePath}
+		}
+	}
+
+	for _, file := range files {
+		filePath := filepath.Join(dir, filepath.FromSlash(file))
+		data, keep := rendered[file]
+		if !keep {
+			if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove %s: %w", filePath, err)
+			}
+			delete(sources, file)
+			continue
+		}
+		if source, loaded := sources[file]; loaded && bytes.Equal(source, data) {
+			continue
+		}
+		if err := atomicWriteFile(filePath, data); err != nil {
+			return err
+		}
+		sources[file] = data
+	}
+	return nil
+}
+
+// itemFile returns the file of a split roadmap an item is saved to: the one
+// it was loaded from, else its parent's for a new task, else a file of its
+// own in its family's directory for a new milestone
+func itemFile(roadmap *types.Roadmap, id string) string {
+	if file, ok := roadmap.Files[id]; ok {
+		return file
+	}
+
+	file := rootFileName
+	if task := roadmap.GetTaskByID(id); task != nil {
+		file = itemFile(roadmap, task.ParentID)
+	} else if milestone := roadmap.GetMilestoneByID(id); milestone != nil {
+		family := milestone.Family
+		if family == "" {
+			if parsed, err := types.ParseID(id); err == nil {
+				family = parsed.Family
+			}
+		}
+		if family != "" {
+			file = path.Join(family, id+".yml")
+		}
+	}
+
+	roadmap.Files[id] = file
+	return file
+}
+
+// SplitRoadmap moves a single-file roadmap into a directory of files, one
+// per milestone, and returns the files written. Comments in the single
+// file are not carried over.
+func SplitRoadmap(filePath, dir string) ([]string, error) {
+	if _, err := os.Stat(filePath); err != nil {
+		return nil, fmt.Errorf("no roadmap to split: %w", err)
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("%s already exists and is not empty", dir)
+	}
+
+	// The directory's lock sits beside it
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+	unlock, err := lockLayouts(filePath, dir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	roadmap, err := LoadRoadmapFromFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	roadmap.Source = nil
+	roadmap.Files = make(map[string]string)
+	roadmap.Sources = make(map[string][]byte)
+	if err := SaveRoadmapToFile(roadmap, dir); err != nil {
+		return nil, err
+	}
+	if err := os.Remove(filePath); err != nil {
+		return nil, fmt.Errorf("roadmap split but %s not removed: %w", filePath, err)
+	}
+
+	files := make([]string, 0, len(roadmap.Sources))
+	for file := range roadmap.Sources {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// JoinRoadmap merges a split roadmap back into a single file and removes
+// the directory's roadmap files
+func JoinRoadmap(dir, filePath string) error {
+	if !IsRoadmapDir(dir) {
+		return fmt.Errorf("no split roadmap in %s", dir)
+	}
+	if _, err := os.Stat(filePath); err == nil {
+		return fmt.Errorf("%s already exists", filePath)
+	}
+
+	unlock, err := lockLayouts(filePath, dir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	roadmap, err := LoadRoadmapFromFile(dir)
+	if err != nil {
+		return err
+	}
+	files, err := roadmapFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	roadmap.Files, roadmap.Sources = nil, nil
+	if err := SaveRoadmapToFile(roadmap, filePath); err != nil {
+		return err
+	}
+
+	// Directories still holding other files are left in place
+	for _, file := range files {
+		if err := os.Remove(filepath.Join(dir, filepath.FromSlash(file))); err != nil {
+			return fmt.Errorf("roadmap joined but %s not removed: %w", file, err)
+		}
+		if family := path.Dir(file); family != "." {
+			os.Remove(filepath.Join(dir, family))
+		}
+	}
+	os.Remove(dir)
+	return nil
+}
+
+// lockLayouts takes the locks of both layouts of a roadmap while it moves
+// between them, the single file's first, so that commands using either
+// path wait for the move
+func lockLayouts(filePath, dir string) (func(), error) {
+	unlockFile, err := lockRoadmap(filePath)
+	if err != nil {
+		return nil, err
+	}
+	unlockDir, err := lockRoadmap(dir)
+	if err != nil {
+		unlockFile()
+		return nil, err
+	}
+	return func() {
+		unlockDir()
+		unlockFile()
+	}, nil
+}
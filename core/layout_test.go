@@ -0,0 +1,233 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/thusai/spiral/types"
+)
+
+// splitSource is a single-file roadmap with two families and a shared section
+const splitSource = `schema_version: 2
+families:
+  - code: D
+    name: Data
+  - code: E
+    name: Editor
+milestones:
+  - id: D1
+    family: D
+    title: Ingest
+    status: in-progress
+  - id: D2
+    family: D
+    title: Reporting
+    status: planned
+  - id: E1
+    family: E
+    title: Undo
+    status: planned
+tasks:
+  - id: D1.1
+    parent_id: D1
+    title: Parse events
+    status: in-progress
+  - id: D1.1.1
+    parent_id: D1.1
+    title: Parse dates
+    status: in-progress
+releases:
+  - name: v2
+    status: planned
+`
+
+func TestSplitEditJoin(t *testing.T) {
+	inTempDir(t)
+	writeFile(t, RoadmapFile, splitSource)
+	original, err := LoadRoadmapFromFile(RoadmapFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join("plan", "roadmap")
+	files, err := SplitRoadmap(RoadmapFile, dir)
+	if err != nil {
+		t.Fatalf("SplitRoadmap: %v", err)
+	}
+	if want := []string{"D/D1.yml", "D/D2.yml", "E/E1.yml", "roadmap.yml"}; !reflect.DeepEqual(files, want) {
+		t.Fatalf("split into %v, want %v", files, want)
+	}
+	if _, err := os.Stat(RoadmapFile); !os.IsNotExist(err) {
+		t.Errorf("%s left behind after splitting", RoadmapFile)
+	}
+
+	// An edit only touches the file of the item changed
+	before := readFiles(t, dir, files)
+	_, err = UpdateRoadmap(NewYAMLStore(dir), func(roadmap *types.Roadmap) error {
+		return UpdateTask(roadmap, "D1.1.1", map[string]interface{}{"title": "Parse timestamps"})
+	})
+	if err != nil {
+		t.Fatalf("UpdateRoadmap: %v", err)
+	}
+	for file, data := range readFiles(t, dir, files) {
+		if changed := string(data) != string(before[file]); changed != (file == "D/D1.yml") {
+			t.Errorf("%s changed: %v", file, changed)
+		}
+	}
+
+	if err := JoinRoadmap(dir, RoadmapFile); err != nil {
+		t.Fatalf("JoinRoadmap: %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("%s left behind after joining", dir)
+	}
+	joined, err := LoadRoadmapFromFile(RoadmapFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	original.GetTaskByID("D1.1.1").Title = "Parse timestamps"
+	for _, roadmap := range []*types.Roadmap{original, joined} {
+		for i := range roadmap.Tasks {
+			roadmap.Tasks[i].Details = types.Details{}
+		}
+		for i := range roadmap.Milestones {
+			roadmap.Milestones[i].Details = types.Details{}
+		}
+		roadmap.Source, roadmap.Files, roadmap.Sources = nil, nil, nil
+	}
+	if !reflect.DeepEqual(joined, original) {
+		t.Errorf("joined roadmap differs:\n%+v\nwant:\n%+v", joined, original)
+	}
+}
+
+func TestItemFile(t *testing.T) {
+	roadmap := &types.Roadmap{
+		Milestones: []types.Milestone{
+			{ID: "D1", Family: "D"},
+			{ID: "D2", Family: "D"},
+			{ID: "OPS3"},
+			{ID: "legacy"},
+		},
+		Tasks: []types.Task{
+			{ID: "D1.1", ParentID: "D1"},
+			{ID: "D1.1.1", ParentID: "D1.1"},
+			{ID: "D2.1", ParentID: "D2"},
+		},
+		Files: map[string]string{"D1": "D/ingest.yml"},
+	}
+
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{id: "D1", want: "D/ingest.yml"},
+		{id: "D1.1.1", want: "D/ingest.yml"},
+		{id: "D2.1", want: "D/D2.yml"},
+		{id: "OPS3", want: "OPS/OPS3.yml"},
+		{id: "legacy", want: rootFileName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			if got := itemFile(roadmap, tt.id); got != tt.want {
+				t.Errorf("itemFile(%s) = %s, want %s", tt.id, got, tt.want)
+			}
+			if got := roadmap.Files[tt.id]; got != tt.want {
+				t.Errorf("Files[%s] = %s, want it remembered as %s", tt.id, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveRoadmapDirConflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   func(roadmap *types.Roadmap)
+		meantime map[string]string // files written after loading
+		conflict bool
+	}{
+		{
+			name:   "unchanged files",
+			change: func(roadmap *types.Roadmap) { roadmap.Milestones[0].Status = "done" },
+		},
+		{
+			name:     "edited file",
+			change:   func(roadmap *types.Roadmap) { roadmap.Milestones[0].Status = "done" },
+			meantime: map[string]string{"D/D1.yml": "milestones:\n  - id: D1\n    title: Ingest # renamed\n"},
+			conflict: true,
+		},
+		{
+			name:     "edit to a file the save leaves alone",
+			change:   func(roadmap *types.Roadmap) { roadmap.Milestones[0].Status = "done" },
+			meantime: map[string]string{"E/E1.yml": "milestones:\n  - id: E1\n    title: Redo\n"},
+			conflict: true,
+		},
+		{
+			name: "new milestone's file created meanwhile",
+			change: func(roadmap *types.Roadmap) {
+				roadmap.Milestones = append(roadmap.Milestones, types.Milestone{ID: "D3", Family: "D", Title: "Alerts", Status: "planned"})
+			},
+			meantime: map[string]string{"D/D3.yml": "milestones:\n  - id: D3\n    title: Alerting\n"},
+			conflict: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inTempDir(t)
+			writeFile(t, RoadmapFile, splitSource)
+			dir := filepath.Join("plan", "roadmap")
+			files, err := SplitRoadmap(RoadmapFile, dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			before := readFiles(t, dir, files)
+
+			roadmap, err := LoadRoadmapFromFile(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.change(roadmap)
+			for file, content := range tt.meantime {
+				writeFile(t, filepath.Join(dir, filepath.FromSlash(file)), content)
+			}
+
+			err = SaveRoadmapToFile(roadmap, dir)
+			var conflict *ConflictError
+			if errors.As(err, &conflict) != tt.conflict || (!tt.conflict && err != nil) {
+				t.Fatalf("SaveRoadmapToFile error = %v, want conflict %v", err, tt.conflict)
+			}
+			for file, content := range tt.meantime {
+				if data, _ := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file))); string(data) != content {
+					t.Errorf("the edit to %s made meanwhile was overwritten:\n%s", file, data)
+				}
+			}
+			if !tt.conflict {
+				return
+			}
+			for file, data := range readFiles(t, dir, files) {
+				if _, edited := tt.meantime[file]; !edited && string(data) != string(before[file]) {
+					t.Errorf("%s written despite the conflict:\n%s", file, data)
+				}
+			}
+		})
+	}
+}
+
+// readFiles reads files relative to dir
+func readFiles(t *testing.T, dir string, files []string) map[string][]byte {
+	t.Helper()
+	contents := make(map[string][]byte)
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents[file] = data
+	}
+	return contents
+}
@@ -0,0 +1,157 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/thusai/spiral/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Warn reports problems that don't stop a command, such as a roadmap in an
+// older layout; cmd points it at the terminal
+var Warn = func(message string) {}
+
+// legacyFields names keys from older layouts that the loader converts
+var legacyFields = map[string]string{
+	"subtasks": "subtasks now live in tasks:",
+	"in_cycle": "in_cycle is now cycle_status: in-cycle or a cycle's items",
+}
+
+// legacyItem holds the fields of older layouts that the current types drop
+type legacyItem struct {
+	ID      string `yaml:"id"`
+	InCycle bool   `yaml:"in_cycle"`
+}
+
+// legacyLayout is a roadmap file as older versions of spiral wrote it
+type legacyLayout struct {
+	Milestones []legacyItem `yaml:"milestones"`
+	Tasks      []legacyItem `yaml:"tasks"`
+	Subtasks   []legacyItem `yaml:"subtasks"`
+}
+
+// legacySubtasks is the separate subtasks: section of example.yml-style files
+type legacySubtasks struct {
+	Subtasks []types.Task `yaml:"subtasks"`
+}
+
+// upgradeLegacy converts the older layouts still found in the wild: the
+// separate subtasks: section and in_cycle flags of example.yml, and
+// milestones that track progress only in release_status with no family,
+// as the old internal/types model wrote them. It returns a note for each
+// change made.
+func upgradeLegacy(data []byte, roadmap *types.Roadmap) ([]string, error) {
+	var notes []string
+	note := func(format string, args ...interface{}) {
+		notes = append(notes, fmt.Sprintf(format, args...))
+	}
+
+	var subtasks legacySubtasks
+	if err := yaml.Unmarshal(data, &subtasks); err != nil {
+		return nil, fmt.Errorf("failed to parse roadmap YAML: %w", err)
+	}
+	moved := 0
+	for _, subtask := range subtasks.Subtasks {
+		if roadmap.GetTaskByID(subtask.ID) != nil {
+			note("subtask %s is already in tasks:; kept the tasks: entry", subtask.ID)
+			continue
+		}
+		roadmap.Tasks = append(roadmap.Tasks, subtask)
+		moved++
+	}
+	if moved > 0 {
+		note("moved %d item(s) from subtasks: into tasks:", moved)
+	}
+
+	var layout legacyLayout
+	if err := yaml.Unmarshal(data, &layout); err != nil {
+		return nil, fmt.Errorf("failed to parse roadmap YAML: %w", err)
+	}
+	cycleWorkflow := types.WorkflowFor(types.WorkflowCycleStatus)
+	inCycle := cycleWorkflow.Working()
+	for _, item := range layout.Milestones {
+		if !item.InCycle {
+			continue
+		}
+		milestone := roadmap.GetMilestoneByID(item.ID)
+		if milestone != nil && !cycleWorkflow.IsWorking(milestone.CycleStatus) {
+			milestone.CycleStatus = inCycle
+			note("%s: in_cycle: true → cycle_status: %s", item.ID, inCycle)
+		}
+	}
+	for _, item := range append(layout.Tasks, layout.Subtasks...) {
+		if !item.InCycle {
+			continue
+		}
+		if cycle := roadmap.ActiveCycle(); cycle != nil {
+			if !cycle.HasItem(item.ID) {
+				cycle.Items = append(cycle.Items, item.ID)
+			}
+			note("%s: in_cycle: true → planned into cycle %s", item.ID, cycle.Name)
+			continue
+		}
+		milestone := roadmap.GetMilestoneByID(rootMilestoneID(item.ID))
+		if milestone == nil {
+			note("%s: dropped in_cycle: true (no milestone or active cycle to carry it)", item.ID)
+			continue
+		}
+		if !cycleWorkflow.IsWorking(milestone.CycleStatus) {
+			milestone.CycleStatus = inCycle
+			note("%s: in_cycle: true → its milestone %s is now %s", item.ID, milestone.ID, inCycle)
+		} else {
+			note("%s: dropped in_cycle: true (its milestone %s is already %s)", item.ID, milestone.ID, milestone.CycleStatus)
+		}
+	}
+
+	// internal/types milestones: no family, progress kept in release_status
+	for i := range roadmap.Milestones {
+		milestone := &roadmap.Milestones[i]
+		if milestone.Family == "" {
+			if id, err := types.ParseID(milestone.ID); err == nil {
+				milestone.Family = id.Family
+				note("%s: family %s taken from its ID", milestone.ID, id.Family)
+			}
+		}
+		if milestone.Status == "" && milestone.ReleaseStatus != "" {
+			milestone.Status = statusFromReleaseStatus(milestone.ReleaseStatus)
+			note("%s: status %s taken from release_status %s", milestone.ID, milestone.Status, milestone.ReleaseStatus)
+		}
+	}
+
+	return notes, nil
+}
+
+// statusFromReleaseStatus maps the old release_status values onto the
+// status workflow by their role: parked and planned work hasn't started.
+// Values the workflow already has are kept.
+func statusFromReleaseStatus(releaseStatus string) string {
+	workflow := types.WorkflowFor(types.WorkflowStatus)
+	if workflow.State(releaseStatus) != nil && releaseStatus != "parked" {
+		return releaseStatus
+	}
+
+	switch releaseStatus {
+	case "parked", "planned":
+		return workflow.Initial()
+	case "in-progress":
+		return workflow.Working()
+	case "done":
+		if done := workflow.Completed(); done != "" {
+			return done
+		}
+	case "cancelled":
+		if dropped := workflow.Dropped(); dropped != "" {
+			return dropped
+		}
+	}
+	return releaseStatus
+}
+
+// rootMilestoneID returns the milestone an item sits under
+func rootMilestoneID(id string) string {
+	parsed, err := types.ParseID(id)
+	if err != nil {
+		return ""
+	}
+	return types.ID{Family: parsed.Family, Path: parsed.Path[:1]}.String()
+}
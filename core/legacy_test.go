@@ -0,0 +1,216 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/thusai/spiral/types"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUpgradeLegacy(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		check  func(t *testing.T, roadmap *types.Roadmap)
+		notes  []string
+	}{
+		{
+			name:   "current layout",
+			source: "milestones:\n  - id: D1\n    family: D\n    status: planned\n",
+		},
+		{
+			name: "subtasks section",
+			source: `milestones:
+  - id: D1
+    family: D
+tasks:
+  - id: D1.1
+    parent_id: D1
+subtasks:
+  - id: D1.1
+    parent_id: D1
+  - id: D1.1.1
+    parent_id: D1.1
+`,
+			check: func(t *testing.T, roadmap *types.Roadmap) {
+				if len(roadmap.Tasks) != 2 || roadmap.GetTaskByID("D1.1.1") == nil {
+					t.Errorf("tasks = %+v, want D1.1 and D1.1.1", roadmap.Tasks)
+				}
+			},
+			notes: []string{
+				"subtask D1.1 is already in tasks:; kept the tasks: entry",
+				"moved 1 item(s) from subtasks: into tasks:",
+			},
+		},
+		{
+			name: "in_cycle without an active cycle",
+			source: `milestones:
+  - id: D1
+    family: D
+    in_cycle: true
+  - id: E1
+    family: E
+tasks:
+  - id: E1.1
+    parent_id: E1
+    in_cycle: true
+  - id: X1.1
+    parent_id: X1
+    in_cycle: true
+`,
+			check: func(t *testing.T, roadmap *types.Roadmap) {
+				for _, id := range []string{"D1", "E1"} {
+					if got := roadmap.GetMilestoneByID(id).CycleStatus; got != "in-cycle" {
+						t.Errorf("%s cycle_status = %q, want in-cycle", id, got)
+					}
+				}
+			},
+			notes: []string{
+				"D1: in_cycle: true → cycle_status: in-cycle",
+				"E1.1: in_cycle: true → its milestone E1 is now in-cycle",
+				"X1.1: dropped in_cycle: true (no milestone or active cycle to carry it)",
+			},
+		},
+		{
+			name: "in_cycle with an active cycle",
+			source: `milestones:
+  - id: D1
+    family: D
+tasks:
+  - id: D1.1
+    parent_id: D1
+    in_cycle: true
+cycles:
+  - name: week-1
+    status: active
+`,
+			check: func(t *testing.T, roadmap *types.Roadmap) {
+				if items := roadmap.Cycles[0].Items; !reflect.DeepEqual(items, []string{"D1.1"}) {
+					t.Errorf("cycle items = %v, want [D1.1]", items)
+				}
+			},
+			notes: []string{"D1.1: in_cycle: true → planned into cycle week-1"},
+		},
+		{
+			name: "internal/types milestones",
+			source: `milestones:
+  - id: D1
+    release_status: parked
+  - id: D2
+    release_status: in-progress
+  - id: D3
+    release_status: done
+    status: planned
+`,
+			check: func(t *testing.T, roadmap *types.Roadmap) {
+				for id, want := range map[string]string{"D1": "planned", "D2": "in-progress", "D3": "planned"} {
+					if got := roadmap.GetMilestoneByID(id).Status; got != want {
+						t.Errorf("%s status = %q, want %q", id, got, want)
+					}
+				}
+			},
+			notes: []string{
+				"D1: family D taken from its ID",
+				"D1: status planned taken from release_status parked",
+				"D2: family D taken from its ID",
+				"D2: status in-progress taken from release_status in-progress",
+				"D3: family D taken from its ID",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var roadmap types.Roadmap
+			if err := yaml.Unmarshal([]byte(tt.source), &roadmap); err != nil {
+				t.Fatal(err)
+			}
+
+			notes, err := upgradeLegacy([]byte(tt.source), &roadmap)
+			if err != nil {
+				t.Fatalf("upgradeLegacy: %v", err)
+			}
+			if strings.Join(notes, "\n") != strings.Join(tt.notes, "\n") {
+				t.Errorf("notes:\n%s\nwant:\n%s", strings.Join(notes, "\n"), strings.Join(tt.notes, "\n"))
+			}
+			if tt.check != nil {
+				tt.check(t, &roadmap)
+			}
+		})
+	}
+}
+
+func TestStatusFromReleaseStatus(t *testing.T) {
+	tests := map[string]string{
+		"parked":      "planned",
+		"planned":     "planned",
+		"in-progress": "in-progress",
+		"done":        "done",
+		"cancelled":   "cancelled",
+		"blocked":     "blocked",
+		"shipped":     "shipped",
+	}
+	for releaseStatus, want := range tests {
+		if got := statusFromReleaseStatus(releaseStatus); got != want {
+			t.Errorf("statusFromReleaseStatus(%s) = %s, want %s", releaseStatus, got, want)
+		}
+	}
+}
+
+func TestLoadLegacyWarning(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		warning string // "" for none
+	}{
+		{
+			name:   "current file",
+			source: "schema_version: 2\nmilestones:\n  - id: D1\n    family: D\n    title: Ingest\n",
+		},
+		{
+			name:    "unversioned file",
+			source:  "milestones:\n  - id: D1\n    family: D\n    title: Ingest\n",
+			warning: "read with 1 conversion(s)",
+		},
+		{
+			name:    "unversioned legacy file",
+			source:  "milestones:\n  - id: D1\n    title: Ingest\n    in_cycle: true\n",
+			warning: "read with 3 conversion(s)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inTempDir(t)
+			writeFile(t, RoadmapFile, tt.source)
+			var warnings []string
+			Warn = func(message string) { warnings = append(warnings, message) }
+			defer func() { Warn = func(string) {} }()
+
+			roadmap, err := LoadRoadmapFromFile(RoadmapFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.warning == "" {
+				if len(warnings) > 0 || len(roadmap.Migrated) > 0 {
+					t.Errorf("warnings %q and migrations %q, want none", warnings, roadmap.Migrated)
+				}
+				return
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0], tt.warning) {
+				t.Fatalf("warnings = %q, want one saying %q", warnings, tt.warning)
+			}
+			// spiral migrate lists exactly what the warning counts
+			if want := fmt.Sprintf("read with %d conversion(s)", len(roadmap.Migrated)); !strings.Contains(warnings[0], want) {
+				t.Errorf("warning %q disagrees with the %d migration note(s) %q", warnings[0], len(roadmap.Migrated), roadmap.Migrated)
+			}
+			if last := roadmap.Migrated[len(roadmap.Migrated)-1]; last != "schema_version 1 → 2" {
+				t.Errorf("last migration note = %q, want the schema bump", last)
+			}
+		})
+	}
+}
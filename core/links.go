@@ -0,0 +1,48 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thusai/spiral/types"
+)
+
+// AddLink attaches a link to an item. An empty kind is detected from the URL.
+func AddLink(roadmap *types.Roadmap, id string, link types.Link) (types.Link, error) {
+	details := roadmap.DetailsOf(id)
+	if details == nil {
+		return link, fmt.Errorf("item %s not found", id)
+	}
+	if err := types.ValidateLinkURL(link.URL); err != nil {
+		return link, err
+	}
+	if link.Kind == "" {
+		link.Kind = types.DetectLinkKind(link.URL)
+	}
+	if !types.IsValidLinkKind(link.Kind) {
+		return link, fmt.Errorf("invalid link kind: %s (use %s)", link.Kind, strings.Join(types.LinkKinds, ", "))
+	}
+	if details.HasLink(link.URL) {
+		return link, fmt.Errorf("%s already links to %s", id, link.URL)
+	}
+
+	details.Links = append(details.Links, link)
+	recordChange(roadmap, id, "links", "", link.URL, "added")
+	return link, nil
+}
+
+// RemoveLink detaches the link to rawURL from an item
+func RemoveLink(roadmap *types.Roadmap, id, rawURL string) error {
+	details := roadmap.DetailsOf(id)
+	if details == nil {
+		return fmt.Errorf("item %s not found", id)
+	}
+	for i, link := range details.Links {
+		if link.URL == rawURL {
+			details.Links = append(details.Links[:i], details.Links[i+1:]...)
+			recordChange(roadmap, id, "links", rawURL, "", "removed")
+			return nil
+		}
+	}
+	return fmt.Errorf("%s has no link to %s", id, rawURL)
+}
@@ -0,0 +1,198 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/thusai/spiral/types"
+)
+
+// LockTimeout is how long to wait for another spiral process to release a
+// roadmap before giving up. Commands only hold the lock to read, change and
+// write the roadmap; gate commands run before it is taken (see PrepareGates).
+var LockTimeout = 10 * time.Second
+
+// staleLockAge is how old a lock must be before it is assumed abandoned,
+// long enough for a gate command to run under it when a retried update
+// needs it again
+const staleLockAge = 30 * time.Minute
+
+// conflictRetries bounds how often UpdateRoadmap reapplies a change to
+// a file that keeps changing underneath it
+const conflictRetries = 3
+
+// ConflictError reports a roadmap file that changed on disk after it was
+// loaded, so saving would overwrite someone else's edit
+type ConflictError struct {
+	Path string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s was changed by someone else after it was loaded; nothing was written, re-run the command to apply your change on top", e.Path)
+}
+
+// lockInfo is written into a lock file to say who holds it
+type lockInfo struct {
+	PID   int       `json:"pid"`
+	Host  string    `json:"host"`
+	Actor string    `json:"actor,omitempty"`
+	At    time.Time `json:"at"`
+}
+
+// held counts the locks this process holds, so that saving inside
+// UpdateRoadmap doesn't wait on its own lock
+var (
+	heldMu sync.Mutex
+	held   = make(map[string]int)
+)
+
+// checkUnchanged fails with a *ConflictError when the file no longer holds
+// what the roadmap was loaded from
+func checkUnchanged(roadmap *types.Roadmap, filePath string) error {
+	if roadmap.Source == nil {
+		return nil
+	}
+	return checkFileUnchanged(filePath, roadmap.Source)
+}
+
+// checkFileUnchanged fails with a *ConflictError when a file no longer
+// holds source; a file that has since been removed is not a conflict
+func checkFileUnchanged(filePath string, source []byte) error {
+	current, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read roadmap file: %w", err)
+	}
+	if sha256.Sum256(current) != sha256.Sum256(source) {
+		return &ConflictError{Path: filePath}
+	}
+	return nil
+}
+
+// lockRoadmap takes the advisory lock on a roadmap file, a sibling file
+// created exclusively, waiting up to LockTimeout for other holders and
+// clearing locks left behind by processes that are gone
+func lockRoadmap(filePath string) (func(), error) {
+	lockPath := filePath + ".lock"
+
+	heldMu.Lock()
+	if held[lockPath] > 0 {
+		held[lockPath]++
+		heldMu.Unlock()
+		return func() { releaseLock(lockPath) }, nil
+	}
+	heldMu.Unlock()
+
+	host, _ := os.Hostname()
+	info, err := json.Marshal(lockInfo{PID: os.Getpid(), Host: host, Actor: Actor, At: now()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal lock: %w", err)
+	}
+
+	deadline := time.Now().Add(LockTimeout)
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, writeErr := file.Write(info)
+			closeErr := file.Close()
+			if writeErr != nil || closeErr != nil {
+				os.Remove(lockPath)
+				return nil, fmt.Errorf("failed to write lock file %s", lockPath)
+			}
+			heldMu.Lock()
+			held[lockPath] = 1
+			heldMu.Unlock()
+			return func() { releaseLock(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create lock file: %w", err)
+		}
+
+		holder, stale := readLock(lockPath)
+		if stale {
+			breakStaleLock(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%s is locked by %s; if no spiral command is running, remove %s", filePath, holder, lockPath)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// breakStaleLock clears a lock found stale. Another process may have broken
+// it and taken a fresh lock since it was read, so the lock is first renamed
+// aside, which only one process can do, and what was moved is checked again:
+// a live lock is put back unless a new one has already been taken.
+func breakStaleLock(lockPath string) {
+	moved := fmt.Sprintf("%s.stale-%d-%d", lockPath, os.Getpid(), time.Now().UnixNano())
+	if err := os.Rename(lockPath, moved); err != nil {
+		return
+	}
+	if _, stale := readLock(moved); !stale {
+		if err := os.Link(moved, lockPath); err != nil && !os.IsExist(err) {
+			// No hard links on this file system; fall back to renaming
+			os.Rename(moved, lockPath)
+			return
+		}
+	}
+	os.Remove(moved)
+}
+
+// releaseLock drops one hold on a lock, removing the file with the last
+func releaseLock(lockPath string) {
+	heldMu.Lock()
+	defer heldMu.Unlock()
+	held[lockPath]--
+	if held[lockPath] <= 0 {
+		delete(held, lockPath)
+		os.Remove(lockPath)
+	}
+}
+
+// readLock describes who holds a lock and whether it is stale: its process
+// has exited, or it is older than staleLockAge
+func readLock(lockPath string) (string, bool) {
+	stat, err := os.Stat(lockPath)
+	if err != nil {
+		// Released while we looked; try again straight away
+		return "", os.IsNotExist(err)
+	}
+
+	data, err := os.ReadFile(lockPath)
+	var info lockInfo
+	if err != nil || json.Unmarshal(data, &info) != nil {
+		// Possibly still being written; only old garbage is stale
+		return "an unknown process", time.Since(stat.ModTime()) > staleLockAge
+	}
+
+	holder := fmt.Sprintf("pid %d on %s since %s", info.PID, info.Host, info.At.Local().Format("15:04:05"))
+	if info.Actor != "" {
+		holder = info.Actor + " (" + holder + ")"
+	}
+	if time.Since(info.At) > staleLockAge {
+		return holder, true
+	}
+	if host, _ := os.Hostname(); info.Host == host && !processAlive(info.PID) {
+		return holder, true
+	}
+	return holder, false
+}
+
+// processAlive reports whether a local process is still running. Where
+// that can't be told it is assumed to be.
+func processAlive(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return !errors.Is(process.Signal(syscall.Signal(0)), os.ErrProcessDone)
+}
@@ -0,0 +1,305 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thusai/spiral/types"
+)
+
+// inTempDir runs the test from an empty directory, where the history log
+// and gate commands write
+func inTempDir(t *testing.T) string {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	return dir
+}
+
+func TestLockRoadmap(t *testing.T) {
+	host, _ := os.Hostname()
+	exited := exec.Command("true")
+	if err := exited.Run(); err != nil {
+		t.Skip("cannot start a process to find an unused PID")
+	}
+
+	tests := []struct {
+		name     string
+		existing string // lock file content, "" for none
+		wantErr  string
+	}{
+		{name: "free"},
+		{
+			name:     "held by a running process",
+			existing: lockJSON(t, lockInfo{PID: os.Getpid(), Host: "elsewhere", Actor: "dev@example.com", At: time.Now()}),
+			wantErr:  "locked by dev@example.com (pid",
+		},
+		{
+			name:     "holder has exited",
+			existing: lockJSON(t, lockInfo{PID: exited.Process.Pid, Host: host, At: time.Now()}),
+		},
+		{
+			name:     "older than the stale age",
+			existing: lockJSON(t, lockInfo{PID: os.Getpid(), Host: "elsewhere", At: time.Now().Add(-2 * staleLockAge)}),
+		},
+		{
+			name:     "still being written",
+			existing: "{",
+			wantErr:  "locked by an unknown process",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			LockTimeout = 200 * time.Millisecond
+			defer func() { LockTimeout = 10 * time.Second }()
+
+			path := filepath.Join(t.TempDir(), RoadmapFile)
+			if tt.existing != "" {
+				writeFile(t, path+".lock", tt.existing)
+			}
+
+			unlock, err := lockRoadmap(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("lockRoadmap error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("lockRoadmap: %v", err)
+			}
+
+			data, err := os.ReadFile(path + ".lock")
+			var info lockInfo
+			if err != nil || json.Unmarshal(data, &info) != nil || info.PID != os.Getpid() {
+				t.Errorf("lock file holds %q, want this process", data)
+			}
+			unlock()
+			if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+				t.Errorf("lock file left behind after unlock")
+			}
+		})
+	}
+}
+
+func TestLockRoadmapReentrant(t *testing.T) {
+	path := filepath.Join(t.TempDir(), RoadmapFile)
+
+	outer, err := lockRoadmap(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inner, err := lockRoadmap(path)
+	if err != nil {
+		t.Fatalf("second lock in the same process: %v", err)
+	}
+
+	inner()
+	if _, err := os.Stat(path + ".lock"); err != nil {
+		t.Errorf("inner unlock released the outer lock")
+	}
+	outer()
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("lock file left behind after the last unlock")
+	}
+}
+
+func TestBreakStaleLock(t *testing.T) {
+	live := lockJSON(t, lockInfo{PID: os.Getpid(), Host: "elsewhere", At: time.Now()})
+
+	tests := []struct {
+		name     string
+		existing string // lock file content when it is broken
+		want     string // lock file content afterwards, "" for none
+	}{
+		{
+			name:     "stale lock removed",
+			existing: lockJSON(t, lockInfo{PID: os.Getpid(), Host: "elsewhere", At: time.Now().Add(-2 * staleLockAge)}),
+		},
+		{
+			name:     "live lock taken since the check is put back",
+			existing: live,
+			want:     live,
+		},
+		{name: "already broken by another process"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			lockPath := filepath.Join(dir, RoadmapFile+".lock")
+			if tt.existing != "" {
+				writeFile(t, lockPath, tt.existing)
+			}
+
+			breakStaleLock(lockPath)
+
+			data, err := os.ReadFile(lockPath)
+			if tt.want == "" {
+				if !os.IsNotExist(err) {
+					t.Errorf("lock file holds %q, want it removed", data)
+				}
+			} else if string(data) != tt.want {
+				t.Errorf("lock file holds %q, want %q", data, tt.want)
+			}
+			if entries, _ := os.ReadDir(dir); len(entries) > 1 || (len(entries) == 1 && tt.want == "") {
+				t.Errorf("moved lock left behind: %v", entries)
+			}
+		})
+	}
+}
+
+func TestSaveRoadmapConflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		meantime string // written after loading, "" to leave the file alone
+		conflict bool
+	}{
+		{name: "unchanged"},
+		{name: "edited by hand", meantime: "schema_version: 2\nmilestones: []\n# edited\n", conflict: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inTempDir(t)
+			writeFile(t, RoadmapFile, "schema_version: 2\nmilestones: []\n")
+
+			roadmap, err := LoadRoadmapFromFile(RoadmapFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.meantime != "" {
+				writeFile(t, RoadmapFile, tt.meantime)
+			}
+
+			err = SaveRoadmapToFile(roadmap, RoadmapFile)
+			var conflict *ConflictError
+			if errors.As(err, &conflict) != tt.conflict || (!tt.conflict && err != nil) {
+				t.Fatalf("SaveRoadmapToFile error = %v, want conflict %v", err, tt.conflict)
+			}
+			if data, _ := os.ReadFile(RoadmapFile); tt.conflict && string(data) != tt.meantime {
+				t.Errorf("the edit made meanwhile was overwritten:\n%s", data)
+			}
+		})
+	}
+}
+
+// racingStore is a JSON store whose file is edited by someone else right
+// after each of its first edits loads
+type racingStore struct {
+	*JSONStore
+	edits int
+	loads int
+}
+
+func (s *racingStore) Load() (*types.Roadmap, error) {
+	roadmap, err := s.JSONStore.Load()
+	s.loads++
+	if err == nil && s.loads <= s.edits {
+		content := fmt.Sprintf(`{"schema_version": 2, "milestones": [], "tasks": [], "edit": %d}`, s.loads)
+		if err := os.WriteFile(s.Path(), []byte(content), 0644); err != nil {
+			return nil, err
+		}
+	}
+	return roadmap, err
+}
+
+func TestUpdateRoadmapRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		edits    int
+		loads    int
+		conflict bool
+	}{
+		{name: "no interference", edits: 0, loads: 1},
+		{name: "edited once", edits: 1, loads: 2},
+		{name: "edited on every retry", edits: conflictRetries, loads: conflictRetries, conflict: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inTempDir(t)
+			writeFile(t, "roadmap.json", `{"schema_version": 2}`)
+			store := &racingStore{JSONStore: NewJSONStore("roadmap.json"), edits: tt.edits}
+
+			_, err := UpdateRoadmap(store, func(roadmap *types.Roadmap) error {
+				return AddMilestone(roadmap, types.Milestone{ID: "D1", Family: "D", Title: "M", Status: "planned"})
+			})
+			if store.loads != tt.loads {
+				t.Errorf("loaded %d time(s), want %d", store.loads, tt.loads)
+			}
+
+			var conflict *ConflictError
+			if errors.As(err, &conflict) != tt.conflict || (!tt.conflict && err != nil) {
+				t.Fatalf("UpdateRoadmap error = %v, want conflict %v", err, tt.conflict)
+			}
+			if tt.conflict {
+				return
+			}
+			saved, err := NewJSONStore("roadmap.json").Load()
+			if err != nil || saved.GetMilestoneByID("D1") == nil {
+				t.Errorf("D1 was not saved: %v", err)
+			}
+			if _, err := os.Stat("roadmap.json.lock"); !os.IsNotExist(err) {
+				t.Errorf("lock file left behind")
+			}
+		})
+	}
+}
+
+func TestPrepareGates(t *testing.T) {
+	inTempDir(t)
+	roadmap := &types.Roadmap{Milestones: []types.Milestone{{ID: "D1", Family: "D", Gates: []types.Gate{
+		{Name: "count", Run: "echo run >> runs"},
+		{Name: "review"},
+	}}}}
+	runs := func() int {
+		data, _ := os.ReadFile("runs")
+		return strings.Count(string(data), "run")
+	}
+
+	forget := PrepareGates(roadmap.GetMilestoneByID("D1"))
+	if runs() != 1 {
+		t.Fatalf("PrepareGates ran the command %d time(s), want 1", runs())
+	}
+
+	// The prepared result is recorded without running the command again
+	var gateErr *GateError
+	if err := RunGates(roadmap, "D1"); !errors.As(err, &gateErr) || len(gateErr.Failed) != 1 {
+		t.Fatalf("RunGates error = %v, want only the checklist gate failing", err)
+	}
+	if runs() != 1 || !roadmap.Milestones[0].Gates[0].Passed() {
+		t.Errorf("prepared result not used: %d run(s)", runs())
+	}
+
+	forget()
+	RunGates(roadmap, "D1")
+	if runs() != 2 {
+		t.Errorf("forgotten result still used: %d run(s), want 2", runs())
+	}
+}
+
+// lockJSON renders the content of a lock file
+func lockJSON(t *testing.T, info lockInfo) string {
+	t.Helper()
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,330 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/thusai/spiral/types"
+	"gopkg.in/yaml.v3"
+)
+
+// MarkdownStore keeps each milestone and task in a Markdown file of its own,
+// with the item's fields as YAML frontmatter and its notes as the body, so
+// the roadmap can live next to the docs. Families, cycles and releases are
+// kept in roadmap.yml in the same directory. A Markdown file is only an
+// item when its frontmatter id is a roadmap ID or it says spiral: true;
+// every other file is an ordinary doc and left alone.
+type MarkdownStore struct {
+	dir string
+}
+
+// markdownMarker is the frontmatter key that says whether a Markdown file is
+// a roadmap item, for docs whose own id happens to look like one
+const markdownMarker = "spiral"
+
+// NewMarkdownStore returns a store for a directory of Markdown files
+func NewMarkdownStore(dir string) *MarkdownStore {
+	return &MarkdownStore{dir: dir}
+}
+
+// Path returns the directory
+func (s *MarkdownStore) Path() string {
+	return s.dir
+}
+
+// files lists roadmap.yml and every Markdown file below the directory,
+// relative to it, skipping hidden directories
+func (s *MarkdownStore) files() ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(s.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == s.dir && os.IsNotExist(err) {
+				return fs.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() {
+			if path != s.dir && strings.HasPrefix(entry.Name(), ".") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == rootFileName || strings.EqualFold(filepath.Ext(rel), ".md") {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roadmap files: %w", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Load reads every item file and roadmap.yml into one roadmap
+func (s *MarkdownStore) Load() (*types.Roadmap, error) {
+	files, err := s.files()
+	if err != nil {
+		return nil, err
+	}
+
+	roadmap := &types.Roadmap{
+		Milestones: []types.Milestone{},
+		Tasks:      []types.Task{},
+		Files:      make(map[string]string),
+		Sources:    make(map[string][]byte),
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(file)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read roadmap file: %w", err)
+		}
+
+		if file == rootFileName {
+			var shared types.Roadmap
+			if err := yaml.Unmarshal(data, &shared); err != nil {
+				return nil, fmt.Errorf("failed to parse roadmap YAML in %s: %w", file, err)
+			}
+			roadmap.SchemaVersion = shared.SchemaVersion
+			roadmap.Families = shared.Families
+			roadmap.Cycles = shared.Cycles
+			roadmap.Releases = shared.Releases
+			roadmap.Sources[file] = data
+			continue
+		}
+
+		// A doc spiral can't read is left alone rather than failing every command
+		milestone, task, err := parseMarkdownItem(data)
+		if err != nil {
+			Warn(fmt.Sprintf("skipping %s: failed to parse frontmatter: %v", filepath.Join(s.dir, file), err))
+			continue
+		}
+		switch {
+		case milestone != nil:
+			roadmap.Milestones = append(roadmap.Milestones, *milestone)
+			roadmap.Files[milestone.ID] = file
+		case task != nil:
+			roadmap.Tasks = append(roadmap.Tasks, *task)
+			roadmap.Files[task.ID] = file
+		default:
+			continue
+		}
+		roadmap.Sources[file] = data
+	}
+
+	if err := upgradeRoadmap(roadmap); err != nil {
+		return nil, err
+	}
+	return roadmap, nil
+}
+
+// Save writes each item back to its file, and new items to <id>.md
+func (s *MarkdownStore) Save(roadmap *types.Roadmap) error {
+	return saveRoadmap(roadmap, s.dir, func(roadmap *types.Roadmap) error {
+		if roadmap.Files == nil {
+			roadmap.Files = make(map[string]string)
+		}
+		if roadmap.Sources == nil {
+			roadmap.Sources = make(map[string][]byte)
+		}
+		fileOf := func(id string) (string, error) {
+			if file, ok := roadmap.Files[id]; ok {
+				return file, nil
+			}
+			// Never write over a doc that wasn't loaded as an item
+			file := id + ".md"
+			if _, loaded := roadmap.Sources[file]; !loaded {
+				if _, err := os.Stat(filepath.Join(s.dir, file)); err == nil {
+					return "", fmt.Errorf("%s is not a roadmap item; rename it or add %s: true to its frontmatter",
+						filepath.Join(s.dir, file), markdownMarker)
+				}
+			}
+			roadmap.Files[id] = file
+			return file, nil
+		}
+
+		rendered := make(map[string][]byte)
+		shared := &splitFile{
+			SchemaVersion: roadmap.SchemaVersion,
+			Families:      roadmap.Families,
+			Cycles:        roadmap.Cycles,
+			Releases:      roadmap.Releases,
+		}
+		data, err := marshalDocument(shared, roadmap.Sources[rootFileName])
+		if err != nil {
+			return err
+		}
+		rendered[rootFileName] = data
+
+		// The notes go in the body rather than the frontmatter
+		for _, milestone := range roadmap.Milestones {
+			file, err := fileOf(milestone.ID)
+			if err != nil {
+				return err
+			}
+			notes := milestone.Notes
+			milestone.Notes = ""
+			if rendered[file], err = renderMarkdownItem(&milestone, notes, roadmap.Sources[file]); err != nil {
+				return err
+			}
+		}
+		for _, task := range roadmap.Tasks {
+			file, err := fileOf(task.ID)
+			if err != nil {
+				return err
+			}
+			notes := task.Notes
+			task.Notes = ""
+			if rendered[file], err = renderMarkdownItem(&task, notes, roadmap.Sources[file]); err != nil {
+				return err
+			}
+		}
+
+		return writeFiles(s.dir, roadmap.Sources, rendered)
+	})
+}
+
+// Watch polls roadmap.yml and the Markdown files
+func (s *MarkdownStore) Watch(ctx context.Context, changed func()) error {
+	return watchFiles(ctx, changed, func() ([]string, error) {
+		files, err := s.files()
+		if err != nil {
+			return nil, err
+		}
+		for i, file := range files {
+			files[i] = filepath.Join(s.dir, filepath.FromSlash(file))
+		}
+		return files, nil
+	})
+}
+
+// parseMarkdownItem reads a milestone, or a task when parent_id is set,
+// from a Markdown file's frontmatter and body. Only files whose id parses
+// as a roadmap ID, or that are marked spiral: true, are items; others, such
+// as doc pages with an id of their own, return neither.
+func parseMarkdownItem(data []byte) (*types.Milestone, *types.Task, error) {
+	front, body, ok := splitFrontmatter(data)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	var probe struct {
+		ID       string `yaml:"id"`
+		ParentID string `yaml:"parent_id"`
+		Spiral   *bool  `yaml:"spiral"`
+	}
+	if err := yaml.Unmarshal(front, &probe); err != nil {
+		return nil, nil, err
+	}
+	if probe.Spiral != nil {
+		if !*probe.Spiral {
+			return nil, nil, nil
+		}
+		if probe.ID == "" {
+			return nil, nil, fmt.Errorf("marked %s: true but has no id", markdownMarker)
+		}
+	} else if _, err := types.ParseID(probe.ID); err != nil {
+		return nil, nil, nil
+	}
+
+	notes := strings.TrimSpace(string(body))
+	if probe.ParentID != "" {
+		var task types.Task
+		if err := yaml.Unmarshal(front, &task); err != nil {
+			return nil, nil, err
+		}
+		if notes != "" {
+			task.Notes = notes
+		}
+		return nil, &task, nil
+	}
+
+	var milestone types.Milestone
+	if err := yaml.Unmarshal(front, &milestone); err != nil {
+		return nil, nil, err
+	}
+	if notes != "" {
+		milestone.Notes = notes
+	}
+	return &milestone, nil, nil
+}
+
+// renderMarkdownItem writes an item as frontmatter patched into its file's
+// existing frontmatter, followed by the notes. A body that still says the
+// same is kept exactly as written, and so is a spiral marker.
+func renderMarkdownItem(item interface{}, notes string, source []byte) ([]byte, error) {
+	front, body, _ := splitFrontmatter(source)
+
+	var fields yaml.Node
+	if err := fields.Encode(item); err != nil {
+		return nil, fmt.Errorf("failed to marshal roadmap item: %w", err)
+	}
+	var existing yaml.Node
+	if yaml.Unmarshal(front, &existing) == nil && len(existing.Content) > 0 {
+		if marker := mappingValue(existing.Content[0], markdownMarker); marker != nil {
+			key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: markdownMarker}
+			fields.Content = append([]*yaml.Node{key, marker}, fields.Content...)
+		}
+	}
+
+	data, err := marshalDocument(&fields, front)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(string(body)) != notes {
+		body = nil
+		if notes != "" {
+			body = []byte("\n" + notes + "\n")
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteString("---\n")
+	out.Write(data)
+	out.WriteString("---\n")
+	out.Write(body)
+	return out.Bytes(), nil
+}
+
+// splitFrontmatter separates a Markdown file's leading --- delimited YAML
+// block from the body after it
+func splitFrontmatter(data []byte) ([]byte, []byte, bool) {
+	var rest []byte
+	switch {
+	case bytes.HasPrefix(data, []byte("---\n")):
+		rest = data[4:]
+	case bytes.HasPrefix(data, []byte("---\r\n")):
+		rest = data[5:]
+	default:
+		return nil, nil, false
+	}
+
+	for offset := 0; offset <= len(rest); {
+		end := bytes.IndexByte(rest[offset:], '\n')
+		line, next := rest[offset:], len(rest)
+		if end >= 0 {
+			line, next = rest[offset:offset+end], offset+end+1
+		}
+		if string(bytes.TrimRight(line, "\r")) == "---" {
+			return rest[:offset], rest[next:], true
+		}
+		if end < 0 {
+			break
+		}
+		offset = next
+	}
+	return nil, nil, false
+}
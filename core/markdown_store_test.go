@@ -0,0 +1,173 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thusai/spiral/types"
+)
+
+// docsPage is a Docusaurus page: an id of its own, but not a roadmap item
+const docsPage = `---
+id: intro
+sidebar_position: 1
+---
+
+# Welcome
+`
+
+const milestoneFile = `---
+id: D1
+family: D
+title: Login
+status: in-progress
+---
+
+Users on Safari are logged out after a refresh.
+
+- [ ] reproduce
+`
+
+func TestMarkdownStore(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  map[string]string // relative to the store, before loading
+		change func(roadmap *types.Roadmap)
+		want   map[string]string // file content after saving, "" for removed
+		items  int               // items loaded
+	}{
+		{
+			name:  "unchanged notes body kept byte for byte",
+			files: map[string]string{"D1.md": milestoneFile},
+			change: func(roadmap *types.Roadmap) {
+				roadmap.Milestones[0].Status = "done"
+			},
+			want:  map[string]string{"D1.md": strings.Replace(milestoneFile, "status: in-progress", "status: done", 1)},
+			items: 1,
+		},
+		{
+			name:  "changed notes rewrite the body",
+			files: map[string]string{"D1.md": milestoneFile},
+			change: func(roadmap *types.Roadmap) {
+				roadmap.Milestones[0].Notes = "Fixed in 2.1."
+			},
+			want:  map[string]string{"D1.md": "---\nid: D1\nfamily: D\ntitle: Login\nstatus: in-progress\n---\n\nFixed in 2.1.\n"},
+			items: 1,
+		},
+		{
+			name: "plain docs are skipped",
+			files: map[string]string{
+				"D1.md":             milestoneFile,
+				"guide/intro.md":    docsPage,
+				"README.md":         "# Roadmap\n",
+				"v2.md":             "---\nid: v2\nspiral: false\n---\n",
+				"guide/broken.md":   "---\nid: [\n---\n",
+				"guide/no-front.md": "id: D9\n",
+			},
+			change: func(roadmap *types.Roadmap) {
+				roadmap.Milestones[0].Title = "Sign-in"
+			},
+			want: map[string]string{
+				"guide/intro.md": docsPage,
+				"README.md":      "# Roadmap\n",
+				"v2.md":          "---\nid: v2\nspiral: false\n---\n",
+			},
+			items: 1,
+		},
+		{
+			name:  "new item goes to its own file",
+			files: map[string]string{"D1.md": milestoneFile},
+			change: func(roadmap *types.Roadmap) {
+				roadmap.Tasks = append(roadmap.Tasks, types.Task{ID: "D1.1", ParentID: "D1", Title: "Reproduce", Status: "planned", Notes: "On Safari 17."})
+			},
+			want: map[string]string{
+				"D1.md":   milestoneFile,
+				"D1.1.md": "---\nid: D1.1\nparent_id: D1\ntitle: Reproduce\nstatus: planned\n---\n\nOn Safari 17.\n",
+			},
+			items: 1,
+		},
+		{
+			name: "removed item deletes its file",
+			files: map[string]string{
+				"D1.md":          milestoneFile,
+				"login/D1.1.md":  "---\nid: D1.1\nparent_id: D1\ntitle: Reproduce\nstatus: planned\n---\n",
+				"guide/intro.md": docsPage,
+			},
+			change: func(roadmap *types.Roadmap) {
+				roadmap.Tasks = nil
+			},
+			want:  map[string]string{"login/D1.1.md": "", "guide/intro.md": docsPage},
+			items: 2,
+		},
+		{
+			name:  "marked item with its marker kept",
+			files: map[string]string{"login.md": "---\nspiral: true\nid: D1\nfamily: D\ntitle: Login\nstatus: planned\n---\n"},
+			change: func(roadmap *types.Roadmap) {
+				roadmap.Milestones[0].Status = "in-progress"
+			},
+			want:  map[string]string{"login.md": "---\nspiral: true\nid: D1\nfamily: D\ntitle: Login\nstatus: in-progress\n---\n"},
+			items: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inTempDir(t)
+			for file, content := range tt.files {
+				path := filepath.FromSlash(file)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				writeFile(t, path, content)
+			}
+
+			store := NewMarkdownStore(".")
+			roadmap, err := store.Load()
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if got := len(roadmap.Milestones) + len(roadmap.Tasks); got != tt.items {
+				t.Fatalf("loaded %d item(s), want %d", got, tt.items)
+			}
+
+			tt.change(roadmap)
+			if err := store.Save(roadmap); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+
+			for file, want := range tt.want {
+				data, err := os.ReadFile(filepath.FromSlash(file))
+				if want == "" {
+					if !os.IsNotExist(err) {
+						t.Errorf("%s still exists", file)
+					}
+					continue
+				}
+				if string(data) != want {
+					t.Errorf("%s:\n%s\nwant:\n%s", file, data, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkdownStoreKeepsDocsAtNewItemPath(t *testing.T) {
+	inTempDir(t)
+	writeFile(t, "D1.md", "---\nid: D1\nspiral: false\n---\n\nRelease notes.\n")
+
+	store := NewMarkdownStore(".")
+	roadmap, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	roadmap.Milestones = append(roadmap.Milestones, types.Milestone{ID: "D1", Family: "D", Title: "Login", Status: "planned"})
+
+	if err := store.Save(roadmap); err == nil || !strings.Contains(err.Error(), "not a roadmap item") {
+		t.Fatalf("Save error = %v, want the doc refused", err)
+	}
+	if data, _ := os.ReadFile("D1.md"); !strings.Contains(string(data), "Release notes.") {
+		t.Errorf("doc overwritten:\n%s", data)
+	}
+}
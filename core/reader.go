@@ -8,67 +8,13 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-
-
-// ValidateRoadmap performs basic validation on the roadmap structure
-func ValidateRoadmap(roadmap *types.Roadmap) error {
-	// Validate milestones
-	milestoneIDs := make(map[string]bool)
-	for i, milestone := range roadmap.Milestones {
-		if milestone.ID == "" {
-			return fmt.Errorf("milestone at index %d has empty ID", i)
-		}
-		if milestone.Title == "" {
-			return fmt.Errorf("milestone %s has empty title", milestone.ID)
-		}
-		if milestoneIDs[milestone.ID] {
-			return fmt.Errorf("duplicate milestone ID: %s", milestone.ID)
-		}
-		milestoneIDs[milestone.ID] = true
-
-		// Validate enum values
-		if milestone.Priority != "" && !types.IsValidPriority(milestone.Priority) {
-			return fmt.Errorf("milestone %s has invalid priority: %s", milestone.ID, milestone.Priority)
-		}
-
-		if milestone.CycleStatus != "" && !types.IsValidCycleStatus(milestone.CycleStatus) {
-			return fmt.Errorf("milestone %s has invalid cycle_status: %s", milestone.ID, milestone.CycleStatus)
-		}
-	}
-
-	// Validate tasks
-	taskIDs := make(map[string]bool)
-	for i, task := range roadmap.Tasks {
-		if task.ID == "" {
-			return fmt.Errorf("task at index %d has empty ID", i)
-		}
-		if task.Title == "" {
-			return fmt.Errorf("task %s has empty title", task.ID)
-		}
-		if task.ParentID == "" {
-			return fmt.Errorf("task %s has empty parent_id", task.ID)
-		}
-		if taskIDs[task.ID] {
-			return fmt.Errorf("duplicate task ID: %s", task.ID)
-		}
-		taskIDs[task.ID] = true
-
-		// Validate parent exists
-		if !milestoneIDs[task.ParentID] && !taskIDs[task.ParentID] {
-			return fmt.Errorf("task %s references non-existent parent: %s", task.ID, task.ParentID)
-		}
-
-		// Validate enum values
-		if task.Status != "" && !types.IsValidTaskStatus(task.Status) {
-			return fmt.Errorf("task %s has invalid status: %s", task.ID, task.Status)
-		}
+// FindDefaultRoadmapFile looks for a roadmap file in the current directory,
+// preferring a roadmap split across .spiral/roadmap
+func FindDefaultRoadmapFile() (string, error) {
+	if path := RoadmapPath(); path == RoadmapDir {
+		return path, nil
 	}
 
-	return nil
-}
-
-// FindDefaultRoadmapFile looks for a roadmap file in the current directory
-func FindDefaultRoadmapFile() (string, error) {
 	candidates := []string{
 		"spiral.yml",
 		"roadmap.yml", 
@@ -97,7 +43,7 @@ func GetRoadmapStats(roadmap *types.Roadmap) map[string]interface{} {
 	for _, milestone := range roadmap.Milestones {
 		status := milestone.CycleStatus
 		if status == "" {
-			status = "planned"
+			status = types.WorkflowFor(types.WorkflowCycleStatus).Initial()
 		}
 		milestonesByStatus[status]++
 	}
@@ -107,7 +53,7 @@ func GetRoadmapStats(roadmap *types.Roadmap) map[string]interface{} {
 	for _, task := range roadmap.Tasks {
 		status := task.Status
 		if status == "" {
-			status = "planned"
+			status = types.WorkflowFor(types.WorkflowStatus).Initial()
 		}
 		tasksByStatus[status]++
 	}
@@ -132,12 +78,17 @@ func LoadRoadmap() (*types.Roadmap, error) {
 
 // LoadRoadmapFromFile loads from a specific file path (rename existing function)
 func LoadRoadmapFromFile(filePath string) (*types.Roadmap, error) {
+	if IsRoadmapDir(filePath) {
+		return loadRoadmapDir(filePath)
+	}
+
 	// Check if file exists
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		// Create default roadmap if file doesn't exist
 		roadmap := &types.Roadmap{
-			Milestones: []types.Milestone{},
-			Tasks:      []types.Task{},
+			SchemaVersion: types.CurrentSchemaVersion,
+			Milestones:    []types.Milestone{},
+			Tasks:         []types.Task{},
 		}
 		if err := SaveRoadmapToFile(roadmap, filePath); err != nil {
 			return nil, fmt.Errorf("failed to create default roadmap: %w", err)
@@ -157,6 +108,15 @@ func LoadRoadmapFromFile(filePath string) (*types.Roadmap, error) {
 		return nil, fmt.Errorf("failed to parse roadmap YAML: %w", err)
 	}
 
+	migrated, err := upgradeLegacy(data, &roadmap)
+	if err != nil {
+		return nil, err
+	}
+	if err := upgradeLoaded(&roadmap, filePath, migrated); err != nil {
+		return nil, err
+	}
+	roadmap.Source = data
+
 	// Initialize empty slices if nil
 	if roadmap.Milestones == nil {
 		roadmap.Milestones = []types.Milestone{}
@@ -166,4 +126,89 @@ func LoadRoadmapFromFile(filePath string) (*types.Roadmap, error) {
 	}
 
 	return &roadmap, nil
-} 
\ No newline at end of file
+}
+
+// upgradeLoaded finishes loading a roadmap read from path, whichever
+// layout it is kept in: it reports the legacy conversions already made,
+// notes a schema version bump and upgrades the roadmap to the current one
+func upgradeLoaded(roadmap *types.Roadmap, path string, migrated []string) error {
+	if version := roadmap.SchemaVersion; version < types.CurrentSchemaVersion {
+		if version == 0 {
+			version = 1
+		}
+		migrated = append(migrated, fmt.Sprintf("schema_version %d → %d", version, types.CurrentSchemaVersion))
+	}
+	// Counted once the bump is noted, so the warning agrees with spiral migrate
+	if len(migrated) > 0 {
+		Warn(fmt.Sprintf("%s uses an older layout; it was read with %d conversion(s), which the next save writes back (see spiral migrate)", path, len(migrated)))
+	}
+	roadmap.Migrated = migrated
+
+	return upgradeRoadmap(roadmap)
+}
+
+// upgradeRoadmap brings a roadmap loaded from an older file up to the
+// current schema version. Unversioned files are treated as version 1.
+func upgradeRoadmap(roadmap *types.Roadmap) error {
+	if roadmap.SchemaVersion > types.CurrentSchemaVersion {
+		return fmt.Errorf("roadmap schema_version %d is newer than this version of spiral supports (%d)",
+			roadmap.SchemaVersion, types.CurrentSchemaVersion)
+	}
+	if roadmap.SchemaVersion == 0 {
+		roadmap.SchemaVersion = 1
+	}
+
+	if roadmap.SchemaVersion == 1 {
+		// Version 1 files use the same field names; version 2 only records
+		// that release_status, week, version and success_gate are kept.
+		roadmap.SchemaVersion = 2
+	}
+
+	if rewritten := normalizeIDs(roadmap); len(rewritten) > 0 {
+		Warn(fmt.Sprintf("%d ID(s) are not written in the configured ID grammar; the next save rewrites them (see spiral migrate)", len(rewritten)))
+		roadmap.Migrated = append(roadmap.Migrated, rewritten...)
+	}
+
+	return nil
+}
+
+// normalizeIDs rewrites every item ID, and every reference to one, in the
+// active ID grammar. IDs written before the separator or padding changed
+// then still match the ones typed on the command line and generated for
+// new items. It returns a note for each item renamed.
+func normalizeIDs(roadmap *types.Roadmap) []string {
+	var notes []string
+	rename := func(id *string) {
+		normalized := types.NormalizeID(*id)
+		if normalized == *id {
+			return
+		}
+		notes = append(notes, fmt.Sprintf("%s: ID rewritten as %s", *id, normalized))
+		if file, ok := roadmap.Files[*id]; ok {
+			delete(roadmap.Files, *id)
+			roadmap.Files[normalized] = file
+		}
+		*id = normalized
+	}
+	references := func(ids []string) {
+		for i := range ids {
+			ids[i] = types.NormalizeID(ids[i])
+		}
+	}
+
+	for i := range roadmap.Milestones {
+		milestone := &roadmap.Milestones[i]
+		rename(&milestone.ID)
+		references(milestone.DependsOn)
+	}
+	for i := range roadmap.Tasks {
+		task := &roadmap.Tasks[i]
+		rename(&task.ID)
+		task.ParentID = types.NormalizeID(task.ParentID)
+		references(task.DependsOn)
+	}
+	for i := range roadmap.Cycles {
+		references(roadmap.Cycles[i].Items)
+	}
+	return notes
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thusai/spiral/types"
+)
+
+func TestLoadRoadmapSchemaVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		migrated []string
+		err      string
+	}{
+		{
+			name:     "unversioned",
+			content:  "milestones: []\n",
+			migrated: []string{"schema_version 1 → 2"},
+		},
+		{
+			name:     "version 1",
+			content:  "schema_version: 1\nmilestones: []\n",
+			migrated: []string{"schema_version 1 → 2"},
+		},
+		{
+			name:    "current",
+			content: "schema_version: 2\nmilestones: []\n",
+		},
+		{
+			name:    "newer",
+			content: "schema_version: 3\nmilestones: []\n",
+			err:     "schema_version 3 is newer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), RoadmapFile)
+			writeFile(t, path, tt.content)
+
+			roadmap, err := LoadRoadmapFromFile(path)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("LoadRoadmapFromFile error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadRoadmapFromFile: %v", err)
+			}
+
+			if roadmap.SchemaVersion != types.CurrentSchemaVersion {
+				t.Errorf("SchemaVersion = %d, want %d", roadmap.SchemaVersion, types.CurrentSchemaVersion)
+			}
+			if strings.Join(roadmap.Migrated, "; ") != strings.Join(tt.migrated, "; ") {
+				t.Errorf("Migrated = %q, want %q", roadmap.Migrated, tt.migrated)
+			}
+		})
+	}
+}
+
+func TestNormalizeIDs(t *testing.T) {
+	types.Grammar = types.IDGrammar{Separator: "-", Padding: 2}
+	defer func() { types.Grammar = types.IDGrammar{} }()
+
+	roadmap := &types.Roadmap{
+		Milestones: []types.Milestone{
+			{ID: "OPS3", Family: "OPS"},
+			{ID: "OPS-04", Family: "OPS", Details: types.Details{DependsOn: []string{"OPS3.1"}}},
+		},
+		Tasks: []types.Task{
+			{ID: "OPS3.1", ParentID: "OPS3"},
+		},
+		Cycles: []types.Cycle{{Name: "c1", Items: []string{"OPS3", "OPS-04"}}},
+		Files:  map[string]string{"OPS3": "OPS/OPS3.yml"},
+	}
+
+	notes := normalizeIDs(roadmap)
+	if len(notes) != 2 {
+		t.Errorf("notes = %q, want one per renamed item", notes)
+	}
+
+	for _, check := range []struct{ got, want string }{
+		{roadmap.Milestones[0].ID, "OPS-03"},
+		{roadmap.Milestones[1].ID, "OPS-04"},
+		{roadmap.Milestones[1].DependsOn[0], "OPS-03.1"},
+		{roadmap.Tasks[0].ID, "OPS-03.1"},
+		{roadmap.Tasks[0].ParentID, "OPS-03"},
+		{roadmap.Cycles[0].Items[0], "OPS-03"},
+		{roadmap.Files["OPS-03"], "OPS/OPS3.yml"},
+	} {
+		if check.got != check.want {
+			t.Errorf("got %q, want %q", check.got, check.want)
+		}
+	}
+}
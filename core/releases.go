@@ -0,0 +1,172 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thusai/spiral/types"
+)
+
+// Release risk levels
+const (
+	RiskOnTrack = "on track"
+	RiskAtRisk  = "at risk"
+	RiskLate    = "late"
+	RiskShipped = "shipped"
+)
+
+// dueSoonWindow is how close a release target must be before unfinished
+// work counts as a risk on its own
+const dueSoonWindow = 7
+
+// ReleaseReport summarises a release's scope, progress and risk
+type ReleaseReport struct {
+	Release    *types.Release
+	Milestones []*types.Milestone
+	Finished   int
+	Effort     Effort
+	DaysLeft   *int
+	Risk       string
+	Reasons    []string
+}
+
+// AddRelease defines a new release
+func AddRelease(roadmap *types.Roadmap, release types.Release) error {
+	if release.Name == "" {
+		return fmt.Errorf("release name cannot be empty")
+	}
+	if roadmap.GetRelease(release.Name) != nil {
+		return fmt.Errorf("release %s already exists", release.Name)
+	}
+	if release.Status == "" {
+		release.Status = types.WorkflowFor(types.WorkflowReleaseStatus).Initial()
+	}
+	if err := CheckField(types.SchemaReleases, "status", release.Status); err != nil {
+		return err
+	}
+
+	roadmap.Releases = append(roadmap.Releases, release)
+	recordChange(roadmap, release.Name, "created", "", release.Name, "release")
+	return nil
+}
+
+// SetReleaseStatus changes a release's status, following the transitions
+// of the release_status workflow
+func SetReleaseStatus(roadmap *types.Roadmap, name, status string) error {
+	release := roadmap.GetRelease(name)
+	if release == nil {
+		return fmt.Errorf("release %s not found", name)
+	}
+	if err := CheckField(types.SchemaReleases, "status", status); err != nil {
+		return err
+	}
+	if workflow := types.WorkflowFor(types.WorkflowReleaseStatus); workflow != nil {
+		if err := workflow.CheckTransition(release.Status, status); err != nil {
+			return fmt.Errorf("invalid status change: %w", err)
+		}
+	}
+	if release.Status != status {
+		recordChange(roadmap, name, "status", release.Status, status, "release")
+		release.Status = status
+	}
+	return nil
+}
+
+// AssignRelease sets the release milestones ship in. An empty name takes
+// them out of any release.
+func AssignRelease(roadmap *types.Roadmap, name string, ids []string) error {
+	if name != "" && roadmap.GetRelease(name) == nil {
+		return fmt.Errorf("release %s not found", name)
+	}
+	for _, id := range ids {
+		if roadmap.GetMilestoneByID(id) == nil {
+			if roadmap.GetTaskByID(id) != nil {
+				return fmt.Errorf("%s is a task; releases are assigned per milestone", id)
+			}
+			return fmt.Errorf("milestone %s not found", id)
+		}
+		if err := UpdateMilestone(roadmap, id, map[string]interface{}{"version": name}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReportRelease works out a release's progress and how likely it is to
+// ship on time
+func ReportRelease(roadmap *types.Roadmap, release *types.Release, now time.Time) ReleaseReport {
+	report := ReleaseReport{
+		Release:    release,
+		Milestones: roadmap.ReleaseMilestones(release.Name),
+		Risk:       RiskOnTrack,
+	}
+
+	efforts := RollupEffort(roadmap)
+	for _, milestone := range report.Milestones {
+		report.Effort.Add(efforts[milestone.ID])
+		if types.IsFinishedStatus(milestone.Status) {
+			report.Finished++
+		}
+	}
+
+	if types.WorkflowFor(types.WorkflowReleaseStatus).IsCompleted(release.Status) {
+		report.Risk = RiskShipped
+		return report
+	}
+
+	if days, err := release.Target.DaysFrom(now); err == nil {
+		report.DaysLeft = &days
+	}
+	finished := report.Finished == len(report.Milestones)
+
+	// Look through everything in scope for work that threatens the target
+	var blocked, overdue, lateEnding int
+	target, targetErr := release.Target.Time()
+	checkItem := func(id, status string, details *types.Details) {
+		if types.IsFinishedStatus(status) {
+			return
+		}
+		if types.WorkflowFor(types.WorkflowStatus).IsBlocked(status) || len(UnfinishedDependencies(roadmap, id)) > 0 {
+			blocked++
+		}
+		if IsOverdue(details, status, now) {
+			overdue++
+		}
+		if end, err := details.End().Time(); err == nil && targetErr == nil && end.After(target) {
+			lateEnding++
+		}
+	}
+	for _, milestone := range report.Milestones {
+		checkItem(milestone.ID, milestone.Status, &milestone.Details)
+		for _, id := range roadmap.Descendants(milestone.ID) {
+			task := roadmap.GetTaskByID(id)
+			checkItem(task.ID, task.Status, &task.Details)
+		}
+	}
+
+	if blocked > 0 {
+		report.Reasons = append(report.Reasons, fmt.Sprintf("%d blocked item(s)", blocked))
+	}
+	if overdue > 0 {
+		report.Reasons = append(report.Reasons, fmt.Sprintf("%d overdue item(s)", overdue))
+	}
+	if lateEnding > 0 {
+		report.Reasons = append(report.Reasons, fmt.Sprintf("%d item(s) due after the target date", lateEnding))
+	}
+	if report.DaysLeft != nil && !finished {
+		days := *report.DaysLeft
+		switch {
+		case days < 0:
+			report.Risk = RiskLate
+			report.Reasons = append(report.Reasons, fmt.Sprintf("target passed %d day(s) ago", -days))
+			return report
+		case days <= dueSoonWindow && report.Effort.Total > 0 && report.Effort.Completed < report.Effort.Total/2:
+			report.Reasons = append(report.Reasons, fmt.Sprintf("%d day(s) left with over half the points remaining", days))
+		}
+	}
+
+	if len(report.Reasons) > 0 {
+		report.Risk = RiskAtRisk
+	}
+	return report
+}
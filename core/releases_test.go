@@ -0,0 +1,60 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thusai/spiral/types"
+)
+
+func TestSetReleaseStatus(t *testing.T) {
+	workflows := make(map[string]*types.Workflow)
+	for field, workflow := range types.DefaultWorkflows {
+		workflows[field] = workflow
+	}
+	workflows[types.WorkflowReleaseStatus] = &types.Workflow{States: []types.WorkflowState{
+		{Name: "planned", Transitions: []string{"in-progress", "cancelled"}},
+		{Name: "in-progress", Transitions: []string{"done"}},
+		{Name: "done", Terminal: true, Transitions: []string{"cancelled"}},
+		{Name: "cancelled", Terminal: true, Dropped: true},
+	}}
+	types.Workflows = workflows
+	defer func() { types.Workflows = types.DefaultWorkflows }()
+
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr string
+	}{
+		{name: "allowed transition", from: "planned", to: "in-progress"},
+		{name: "unchanged", from: "done", to: "done"},
+		{name: "not a transition", from: "planned", to: "done", wantErr: "cannot move from planned to done"},
+		{name: "reopening a done release", from: "done", to: "in-progress", wantErr: "allowed from done: cancelled"},
+		{name: "unknown status", from: "planned", to: "shipped", wantErr: "invalid status: shipped"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inTempDir(t)
+			roadmap := &types.Roadmap{Releases: []types.Release{{Name: "v2", Status: tt.from}}}
+
+			err := SetReleaseStatus(roadmap, "v2", tt.to)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("SetReleaseStatus error = %v, want %q", err, tt.wantErr)
+				}
+				if got := roadmap.Releases[0].Status; got != tt.from {
+					t.Errorf("status = %s after a refused change, want %s", got, tt.from)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetReleaseStatus: %v", err)
+			}
+			if got := roadmap.Releases[0].Status; got != tt.to {
+				t.Errorf("status = %s, want %s", got, tt.to)
+			}
+		})
+	}
+}
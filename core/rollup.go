@@ -0,0 +1,69 @@
+package core
+
+import (
+	"github.com/thusai/spiral/types"
+)
+
+// StatusChange records a status set by the rollup
+type StatusChange struct {
+	ID   string
+	From string
+	To   string
+}
+
+// RollupStatuses derives the status of every parent from its children using
+// types.ActiveRollupRules, working up from the deepest tasks. Items with
+// status_locked keep their status, though it still counts towards their own
+// parent. Items whose children match no rule are left alone, and milestones
+// only roll up to done once their recorded gate results pass.
+func RollupStatuses(roadmap *types.Roadmap) []StatusChange {
+	children := make(map[string][]*types.Task)
+	for i := range roadmap.Tasks {
+		task := &roadmap.Tasks[i]
+		children[task.ParentID] = append(children[task.ParentID], task)
+	}
+
+	var changes []StatusChange
+	visited := make(map[string]bool)
+
+	// rollup settles the statuses below id and returns the child statuses
+	var rollup func(id string) []string
+	rollup = func(id string) []string {
+		var statuses []string
+		for _, child := range children[id] {
+			if !visited[child.ID] {
+				visited[child.ID] = true
+				applyRollupRule(child.ID, &child.Status, &child.Details, nil, rollup(child.ID), &changes)
+			}
+			statuses = append(statuses, child.Status)
+		}
+		return statuses
+	}
+
+	for i := range roadmap.Milestones {
+		milestone := &roadmap.Milestones[i]
+		applyRollupRule(milestone.ID, &milestone.Status, &milestone.Details, milestone.Gates, rollup(milestone.ID), &changes)
+	}
+
+	return changes
+}
+
+// applyRollupRule sets status from the first rule matching the child statuses
+func applyRollupRule(id string, status *string, details *types.Details, gates []types.Gate, children []string, changes *[]StatusChange) {
+	if details.StatusLocked || len(children) == 0 {
+		return
+	}
+	for _, rule := range types.ActiveRollupRules() {
+		if rule.Matches(children) {
+			// Fall through to the next rule until the gates pass
+			if types.WorkflowFor(types.WorkflowStatus).IsGated(rule.Then) && !types.GatesPassed(gates) {
+				continue
+			}
+			if *status != rule.Then {
+				*changes = append(*changes, StatusChange{ID: id, From: *status, To: rule.Then})
+				*status = rule.Then
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thusai/spiral/types"
+)
+
+func TestRollupStatuses(t *testing.T) {
+	tests := []struct {
+		name      string
+		milestone types.Milestone
+		tasks     []types.Task
+		want      map[string]string
+		changes   int
+	}{
+		{
+			name:      "all done",
+			milestone: types.Milestone{ID: "D1", Status: "in-progress"},
+			tasks:     tasks("D1.1:done", "D1.2:done"),
+			want:      map[string]string{"D1": "done"},
+			changes:   1,
+		},
+		{
+			name:      "done and cancelled",
+			milestone: types.Milestone{ID: "D1", Status: "in-progress"},
+			tasks:     tasks("D1.1:done", "D1.2:cancelled"),
+			want:      map[string]string{"D1": "done"},
+			changes:   1,
+		},
+		{
+			name:      "all cancelled",
+			milestone: types.Milestone{ID: "D1", Status: "planned"},
+			tasks:     tasks("D1.1:cancelled", "D1.2:cancelled"),
+			want:      map[string]string{"D1": "cancelled"},
+			changes:   1,
+		},
+		{
+			name:      "some started",
+			milestone: types.Milestone{ID: "D1", Status: "planned"},
+			tasks:     tasks("D1.1:done", "D1.2:planned"),
+			want:      map[string]string{"D1": "in-progress"},
+			changes:   1,
+		},
+		{
+			name:      "no rule matches",
+			milestone: types.Milestone{ID: "D1", Status: "blocked"},
+			tasks:     tasks("D1.1:planned", "D1.2:planned"),
+			want:      map[string]string{"D1": "blocked"},
+		},
+		{
+			name:      "no children",
+			milestone: types.Milestone{ID: "D1", Status: "planned"},
+			want:      map[string]string{"D1": "planned"},
+		},
+		{
+			name:      "nested",
+			milestone: types.Milestone{ID: "D1", Status: "planned"},
+			tasks:     tasks("D1.1:planned", "D1.1.1:done", "D1.1.2:done", "D1.2:done"),
+			want:      map[string]string{"D1.1": "done", "D1": "done"},
+			changes:   2,
+		},
+		{
+			name:      "locked parent keeps its status but counts for its own",
+			milestone: types.Milestone{ID: "D1", Status: "planned"},
+			tasks: append(tasks("D1.1.1:done"),
+				types.Task{ID: "D1.1", ParentID: "D1", Status: "in-progress", Details: types.Details{StatusLocked: true}}),
+			want:    map[string]string{"D1.1": "in-progress", "D1": "in-progress"},
+			changes: 1,
+		},
+		{
+			name: "unmet gates hold a milestone back from done",
+			milestone: types.Milestone{ID: "D1", Status: "planned", Gates: []types.Gate{
+				{Name: "review"},
+			}},
+			tasks:   tasks("D1.1:done"),
+			want:    map[string]string{"D1": "in-progress"},
+			changes: 1,
+		},
+		{
+			name: "met gates let it finish",
+			milestone: types.Milestone{ID: "D1", Status: "planned", Gates: []types.Gate{
+				{Name: "review", Checked: true},
+			}},
+			tasks:   tasks("D1.1:done"),
+			want:    map[string]string{"D1": "done"},
+			changes: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roadmap := &types.Roadmap{Milestones: []types.Milestone{tt.milestone}, Tasks: tt.tasks}
+
+			changes := RollupStatuses(roadmap)
+			if len(changes) != tt.changes {
+				t.Errorf("got %d change(s) %v, want %d", len(changes), changes, tt.changes)
+			}
+			for id, want := range tt.want {
+				if got, _ := roadmap.StatusOf(id); got != want {
+					t.Errorf("%s status = %s, want %s", id, got, want)
+				}
+			}
+		})
+	}
+}
+
+// tasks builds tasks from "ID:status" pairs, each under the ID's parent
+func tasks(specs ...string) []types.Task {
+	var list []types.Task
+	for _, spec := range specs {
+		id, status, _ := strings.Cut(spec, ":")
+		parsed, _ := types.ParseID(id)
+		list = append(list, types.Task{ID: id, ParentID: parsed.ParentID(), Status: status})
+	}
+	return list
+}
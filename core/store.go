@@ -0,0 +1,214 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/thusai/spiral/types"
+)
+
+// Store keeps a roadmap somewhere. Every store saves through the same
+// pipeline: locked, rolled up, validated, checked for outside edits and
+// logged to the history.
+type Store interface {
+	// Path is the file or directory the roadmap is kept in
+	Path() string
+
+	// Load reads the roadmap
+	Load() (*types.Roadmap, error)
+
+	// Save writes the roadmap back, refusing with a *ConflictError if the
+	// stored roadmap changed since it was loaded
+	Save(roadmap *types.Roadmap) error
+
+	// Watch calls changed each time the stored roadmap changes, until ctx
+	// is done
+	Watch(ctx context.Context, changed func()) error
+}
+
+// WatchInterval is how often stores look for changes while watching
+var WatchInterval = time.Second
+
+// Default locations of the storage backends
+const (
+	DefaultJSONPath     = "spiral.json"
+	DefaultMarkdownPath = "docs/roadmap"
+)
+
+// OpenStore returns the store a project's storage config selects
+func OpenStore(storage types.Storage) (Store, error) {
+	switch strings.ToLower(storage.Backend) {
+	case "", types.StorageYAML:
+		if storage.Path != "" {
+			return NewYAMLStore(storage.Path), nil
+		}
+		path, err := FindDefaultRoadmapFile()
+		if err != nil {
+			return nil, err
+		}
+		return NewYAMLStore(path), nil
+	case types.StorageJSON:
+		if storage.Path != "" {
+			return NewJSONStore(storage.Path), nil
+		}
+		return NewJSONStore(DefaultJSONPath), nil
+	case types.StorageMarkdown:
+		if storage.Path != "" {
+			return NewMarkdownStore(storage.Path), nil
+		}
+		return NewMarkdownStore(DefaultMarkdownPath), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %s (use %s)", storage.Backend, strings.Join(types.StorageBackends, ", "))
+	}
+}
+
+// UpdateRoadmap runs a read-modify-write cycle on a store while holding its
+// lock. If the stored roadmap is edited by hand in the meantime, mutate is
+// reapplied to a fresh copy instead of overwriting the edit.
+func UpdateRoadmap(store Store, mutate func(*types.Roadmap) error) (*types.Roadmap, error) {
+	unlock, err := lockRoadmap(store.Path())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	for attempt := 1; ; attempt++ {
+		roadmap, err := store.Load()
+		if err != nil {
+			return nil, err
+		}
+		if err := mutate(roadmap); err != nil {
+			return nil, err
+		}
+
+		err = store.Save(roadmap)
+		var conflict *ConflictError
+		if errors.As(err, &conflict) && attempt < conflictRetries {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to save roadmap: %w", err)
+		}
+		return roadmap, nil
+	}
+}
+
+// ValidateStore checks the roadmap in a store against the project schema.
+// YAML files are checked with line numbers; other stores name the file of
+// each violation where they keep one per item.
+func ValidateStore(store Store) ([]Violation, error) {
+	if yamlStore, ok := store.(*YAMLStore); ok {
+		return ValidateRoadmapFile(yamlStore.Path())
+	}
+
+	roadmap, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	err = ValidateRoadmap(roadmap)
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) {
+		return nil, err
+	}
+	for i := range invalid.Violations {
+		invalid.Violations[i].File = roadmap.Files[invalid.Violations[i].ItemID]
+	}
+	return invalid.Violations, nil
+}
+
+// YAMLStore keeps the roadmap in spiral.yml, or split across a directory
+// of YAML files
+type YAMLStore struct {
+	path string
+}
+
+// NewYAMLStore returns a store for a YAML file or split directory
+func NewYAMLStore(path string) *YAMLStore {
+	return &YAMLStore{path: path}
+}
+
+// Path returns the YAML file or directory
+func (s *YAMLStore) Path() string {
+	return s.path
+}
+
+// Load reads the roadmap, creating an empty file if there is none
+func (s *YAMLStore) Load() (*types.Roadmap, error) {
+	return LoadRoadmapFromFile(s.path)
+}
+
+// Save writes the roadmap back, patching the files it came from
+func (s *YAMLStore) Save(roadmap *types.Roadmap) error {
+	return SaveRoadmapToFile(roadmap, s.path)
+}
+
+// Watch polls the YAML file, or every file of a split directory
+func (s *YAMLStore) Watch(ctx context.Context, changed func()) error {
+	return watchFiles(ctx, changed, func() ([]string, error) {
+		if !IsRoadmapDir(s.path) {
+			return []string{s.path}, nil
+		}
+		files, err := roadmapFiles(s.path)
+		if err != nil {
+			return nil, err
+		}
+		for i, file := range files {
+			files[i] = filepath.Join(s.path, filepath.FromSlash(file))
+		}
+		return files, nil
+	})
+}
+
+// watchFiles polls the files list returns, calling changed whenever their
+// names or content differ from the last look, until ctx is done
+func watchFiles(ctx context.Context, changed func(), list func() ([]string, error)) error {
+	last, err := fingerprint(list)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(WatchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			current, err := fingerprint(list)
+			if err != nil {
+				return err
+			}
+			if current != last {
+				last = current
+				changed()
+			}
+		}
+	}
+}
+
+// fingerprint hashes the names and content of the listed files
+func fingerprint(list func() ([]string, error)) ([sha256.Size]byte, error) {
+	var sum [sha256.Size]byte
+	files, err := list()
+	if err != nil {
+		return sum, err
+	}
+
+	hash := sha256.New()
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil && !os.IsNotExist(err) {
+			return sum, fmt.Errorf("failed to read roadmap file: %w", err)
+		}
+		fmt.Fprintf(hash, "%s\x00%d\x00", file, len(data))
+		hash.Write(data)
+	}
+	copy(sum[:], hash.Sum(nil))
+	return sum, nil
+}
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"time"
+
+	"github.com/thusai/spiral/types"
+)
+
+// now is the clock used for item timestamps, truncated to whole seconds so
+// roadmap files stay readable
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+// stampCreated sets the timestamps of an item being added with status
+func stampCreated(details *types.Details, status string, at time.Time) {
+	details.CreatedAt = &at
+	details.UpdatedAt = &at
+	stampStatus(details, "", status, at)
+}
+
+// stampStatus records a status change: moving to a working state, one that
+// is neither the initial state nor terminal, starts the item; entering a
+// terminal state completes it and leaving one reopens it
+func stampStatus(details *types.Details, from, to string, at time.Time) {
+	workflow := types.WorkflowFor(types.WorkflowStatus)
+
+	if to != "" && to != workflow.Initial() && !workflow.IsTerminal(to) && details.StartedAt == nil {
+		details.StartedAt = &at
+	}
+
+	switch {
+	case workflow.IsTerminal(to) && (!workflow.IsTerminal(from) || details.CompletedAt == nil):
+		details.CompletedAt = &at
+	case !workflow.IsTerminal(to):
+		details.CompletedAt = nil
+	}
+}
@@ -0,0 +1,728 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thusai/spiral/config"
+	"github.com/thusai/spiral/types"
+	"gopkg.in/yaml.v3"
+)
+
+// rootFields lists the top-level keys a roadmap file may contain
+var rootFields = map[string]bool{
+	"schema_version":       true,
+	types.SchemaFamilies:   true,
+	types.SchemaMilestones: true,
+	types.SchemaTasks:      true,
+	types.SchemaCycles:     true,
+	types.SchemaReleases:   true,
+}
+
+// Violation describes a single rule a roadmap breaks
+type Violation struct {
+	File    string // set when the roadmap is split across files
+	Line    int    // 0 when the roadmap did not come from a file
+	ItemID  string
+	Field   string
+	Message string
+}
+
+// String formats the violation, prefixed with its line when known
+func (v Violation) String() string {
+	if v.Line > 0 {
+		return fmt.Sprintf("line %d: %s", v.Line, v.Message)
+	}
+	return v.Message
+}
+
+// ValidationError reports every violation found in a roadmap
+type ValidationError struct {
+	File       string
+	Violations []Violation
+}
+
+// Error lists all violations, one per line
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		if e.File != "" && v.Line > 0 {
+			lines[i] = fmt.Sprintf("%s:%d: %s", e.File, v.Line, v.Message)
+		} else {
+			lines[i] = v.String()
+		}
+	}
+
+	if len(lines) == 1 {
+		return lines[0]
+	}
+	return fmt.Sprintf("%d problems:\n  %s", len(lines), strings.Join(lines, "\n  "))
+}
+
+// ValidateRoadmap checks an in-memory roadmap against the project schema
+// and the structural rules (unique IDs, existing parents)
+func ValidateRoadmap(roadmap *types.Roadmap) error {
+	schema, err := config.LoadSchema()
+	if err != nil {
+		return err
+	}
+
+	// Validate the same node tree the writer would produce
+	var doc yaml.Node
+	if err := doc.Encode(roadmap); err != nil {
+		return fmt.Errorf("failed to encode roadmap: %w", err)
+	}
+
+	violations := validateRoadmap(roadmap, &doc, schema)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	// Lines of the encoded tree don't correspond to anything on disk
+	for i := range violations {
+		violations[i].Line = 0
+	}
+	return &ValidationError{Violations: violations}
+}
+
+// ValidateRoadmapFile checks a roadmap file against the project schema and
+// returns every violation with its line number
+func ValidateRoadmapFile(filePath string) ([]Violation, error) {
+	schema, err := config.LoadSchema()
+	if err != nil {
+		return nil, err
+	}
+	if IsRoadmapDir(filePath) {
+		return validateRoadmapDir(filePath, schema)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read roadmap file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse roadmap YAML: %w", err)
+	}
+
+	var roadmap types.Roadmap
+	if err := doc.Decode(&roadmap); err != nil {
+		return nil, fmt.Errorf("failed to parse roadmap YAML: %w", err)
+	}
+
+	return validateRoadmap(&roadmap, &doc, schema), nil
+}
+
+// validateRoadmapDir checks every file of a split roadmap against the
+// schema, then the merged roadmap's structure, naming the file of each
+// violation
+func validateRoadmapDir(dir string, schema *types.Schema) ([]Violation, error) {
+	files, err := roadmapFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []Violation
+	var roadmap types.Roadmap
+	lines := make(map[string]int)
+	itemFiles := make(map[string]string)
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read roadmap file: %w", err)
+		}
+
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse roadmap YAML in %s: %w", file, err)
+		}
+		var part types.Roadmap
+		if err := doc.Decode(&part); err != nil {
+			return nil, fmt.Errorf("failed to parse roadmap YAML in %s: %w", file, err)
+		}
+
+		for _, v := range ValidateDocument(&doc, schema) {
+			v.File = file
+			violations = append(violations, v)
+		}
+		for id, line := range itemLines(&doc) {
+			if _, seen := lines[id]; !seen {
+				lines[id], itemFiles[id] = line, file
+			}
+		}
+		roadmap.Families = append(roadmap.Families, part.Families...)
+		roadmap.Milestones = append(roadmap.Milestones, part.Milestones...)
+		roadmap.Tasks = append(roadmap.Tasks, part.Tasks...)
+		roadmap.Cycles = append(roadmap.Cycles, part.Cycles...)
+		roadmap.Releases = append(roadmap.Releases, part.Releases...)
+	}
+
+	for _, v := range validateStructure(&roadmap, lines) {
+		v.File = itemFiles[v.ItemID]
+		violations = append(violations, v)
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].File != violations[j].File {
+			return violations[i].File < violations[j].File
+		}
+		return violations[i].Line < violations[j].Line
+	})
+	return violations, nil
+}
+
+// CheckField verifies a single field value against the project schema
+func CheckField(section, field, value string) error {
+	schema, err := config.LoadSchema()
+	if err != nil {
+		return err
+	}
+
+	rule, ok := schema.Rule(section, field)
+	if !ok || value == "" || rule.Allows(value) {
+		return nil
+	}
+	return fmt.Errorf("invalid %s: %s (allowed: %s)", field, value, rule.AllowedValues())
+}
+
+// validateRoadmap runs the schema and structural checks, sorted by line
+func validateRoadmap(roadmap *types.Roadmap, doc *yaml.Node, schema *types.Schema) []Violation {
+	violations := ValidateDocument(doc, schema)
+	violations = append(violations, validateStructure(roadmap, itemLines(doc))...)
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Line < violations[j].Line
+	})
+	return violations
+}
+
+// ValidateDocument checks a parsed roadmap document against a schema
+func ValidateDocument(doc *yaml.Node, schema *types.Schema) []Violation {
+	root := doc
+	if root.Kind == yaml.DocumentNode {
+		if len(root.Content) == 0 {
+			return nil
+		}
+		root = root.Content[0]
+	}
+
+	if root.Kind != yaml.MappingNode {
+		return []Violation{{Line: root.Line, Message: "roadmap must be a mapping of sections"}}
+	}
+
+	var violations []Violation
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+
+		if !rootFields[key.Value] {
+			violations = append(violations, Violation{
+				Line:    key.Line,
+				Field:   key.Value,
+				Message: unknownMessage("unknown section: "+key.Value, key.Value),
+			})
+			continue
+		}
+
+		rules := schema.Section(key.Value)
+		if rules == nil {
+			continue
+		}
+		violations = append(violations, validateSection(key.Value, value, rules)...)
+	}
+
+	return violations
+}
+
+// validateSection checks every item in a milestones or tasks sequence
+func validateSection(section string, node *yaml.Node, rules map[string]types.FieldRule) []Violation {
+	if isNull(node) {
+		return nil
+	}
+	if node.Kind != yaml.SequenceNode {
+		return []Violation{{Line: node.Line, Message: fmt.Sprintf("%s must be a list", section)}}
+	}
+
+	var violations []Violation
+	for index, item := range node.Content {
+		label := itemLabel(section, item, index)
+		if item.Kind != yaml.MappingNode {
+			violations = append(violations, Violation{
+				Line:    item.Line,
+				Message: fmt.Sprintf("%s must be a mapping", label),
+			})
+			continue
+		}
+
+		itemID := ""
+		if id := itemKey(item); id != nil {
+			itemID = id.Value
+		}
+
+		present := make(map[string]*yaml.Node)
+		for i := 0; i+1 < len(item.Content); i += 2 {
+			key, value := item.Content[i], item.Content[i+1]
+			present[key.Value] = value
+
+			rule, ok := rules[key.Value]
+			if !ok {
+				violations = append(violations, Violation{
+					Line:    key.Line,
+					ItemID:  itemID,
+					Field:   key.Value,
+					Message: unknownMessage(fmt.Sprintf("%s: unknown field %s", label, key.Value), key.Value),
+				})
+				continue
+			}
+
+			if msg := checkRule(rule, value); msg != "" {
+				violations = append(violations, Violation{
+					Line:    value.Line,
+					ItemID:  itemID,
+					Field:   key.Value,
+					Message: fmt.Sprintf("%s: %s %s", label, key.Value, msg),
+				})
+			}
+		}
+
+		// Report missing required fields in schema order for stable output
+		fields := make([]string, 0, len(rules))
+		for field := range rules {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		for _, field := range fields {
+			if !rules[field].Required {
+				continue
+			}
+			if value, ok := present[field]; !ok || isEmpty(value) {
+				violations = append(violations, Violation{
+					Line:    item.Line,
+					ItemID:  itemID,
+					Field:   field,
+					Message: fmt.Sprintf("%s: missing required field %s", label, field),
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+// checkRule returns a description of how value breaks rule, or ""
+func checkRule(rule types.FieldRule, value *yaml.Node) string {
+	if isNull(value) {
+		return ""
+	}
+
+	switch rule.Type {
+	case types.FieldList:
+		if value.Kind != yaml.SequenceNode {
+			return "must be a list"
+		}
+	case types.FieldMap:
+		if value.Kind != yaml.MappingNode {
+			return "must be a mapping"
+		}
+	case types.FieldInt:
+		if value.Kind != yaml.ScalarNode || value.Tag != "!!int" {
+			return fmt.Sprintf("must be an integer, got %q", value.Value)
+		}
+	case types.FieldNumber:
+		if value.Kind != yaml.ScalarNode || (value.Tag != "!!int" && value.Tag != "!!float") {
+			return fmt.Sprintf("must be a number, got %q", value.Value)
+		}
+	case types.FieldBool:
+		if value.Kind != yaml.ScalarNode || value.Tag != "!!bool" {
+			return fmt.Sprintf("must be true or false, got %q", value.Value)
+		}
+	case types.FieldDate:
+		if value.Kind != yaml.ScalarNode {
+			return "must be a date"
+		}
+		if _, err := types.Date(value.Value).Time(); err != nil {
+			return fmt.Sprintf("must be a date (YYYY-MM-DD), got %q", value.Value)
+		}
+	case types.FieldTime:
+		if value.Kind != yaml.ScalarNode {
+			return "must be a timestamp"
+		}
+		if _, err := time.Parse(time.RFC3339Nano, value.Value); err != nil {
+			return fmt.Sprintf("must be a timestamp (RFC 3339), got %q", value.Value)
+		}
+	case types.FieldString, "":
+		if value.Kind != yaml.ScalarNode {
+			return "must be a single value"
+		}
+	}
+
+	if value.Kind == yaml.ScalarNode && value.Value != "" && !rule.Allows(value.Value) {
+		return fmt.Sprintf("has invalid value %q (allowed: %s)", value.Value, rule.AllowedValues())
+	}
+
+	return ""
+}
+
+// validateStructure checks the rules a schema can't express: unique IDs and
+// parents that exist
+func validateStructure(roadmap *types.Roadmap, lines map[string]int) []Violation {
+	var violations []Violation
+	add := func(id, message string) {
+		violations = append(violations, Violation{Line: lines[id], ItemID: id, Message: message})
+	}
+
+	familyCodes := make(map[string]bool)
+	for _, family := range roadmap.Families {
+		if family.Code == "" {
+			continue
+		}
+		if familyCodes[family.Code] {
+			add(family.Code, fmt.Sprintf("duplicate family code: %s", family.Code))
+		}
+		familyCodes[family.Code] = true
+	}
+
+	milestoneIDs := make(map[string]bool)
+	for _, milestone := range roadmap.Milestones {
+		// Missing IDs are reported by the schema's required rule
+		if milestone.ID == "" {
+			continue
+		}
+		if milestoneIDs[milestone.ID] {
+			add(milestone.ID, fmt.Sprintf("duplicate milestone ID: %s", milestone.ID))
+		}
+		milestoneIDs[milestone.ID] = true
+
+		// Only roadmaps that declare a registry are held to it
+		if len(familyCodes) > 0 && milestone.Family != "" && !familyCodes[milestone.Family] {
+			add(milestone.ID, fmt.Sprintf("milestone %s uses unregistered family: %s", milestone.ID, milestone.Family))
+		}
+	}
+
+	taskIDs := make(map[string]bool)
+	for _, task := range roadmap.Tasks {
+		if task.ID == "" {
+			continue
+		}
+		if taskIDs[task.ID] || milestoneIDs[task.ID] {
+			add(task.ID, fmt.Sprintf("duplicate task ID: %s", task.ID))
+		}
+		taskIDs[task.ID] = true
+	}
+
+	for _, task := range roadmap.Tasks {
+		if task.ID == "" || task.ParentID == "" {
+			continue
+		}
+		if !milestoneIDs[task.ParentID] && !taskIDs[task.ParentID] {
+			add(task.ID, fmt.Sprintf("task %s references non-existent parent: %s", task.ID, task.ParentID))
+		}
+	}
+
+	// Sizes come from the configured size scale rather than the schema
+	checkSize := func(id, size string) {
+		if size != "" && !types.IsValidSize(size) {
+			add(id, fmt.Sprintf("%s has size %q, which is not on the size scale", id, size))
+		}
+	}
+	for _, milestone := range roadmap.Milestones {
+		checkSize(milestone.ID, milestone.Size)
+	}
+	for _, task := range roadmap.Tasks {
+		checkSize(task.ID, task.Size)
+	}
+
+	checkLabels := func(id string, labels []string) {
+		for _, label := range labels {
+			if !types.IsValidLabel(label) {
+				add(id, fmt.Sprintf("%s has invalid label %q", id, label))
+			}
+		}
+	}
+	for _, milestone := range roadmap.Milestones {
+		checkLabels(milestone.ID, milestone.Labels)
+	}
+	for _, task := range roadmap.Tasks {
+		checkLabels(task.ID, task.Labels)
+	}
+
+	checkLinks := func(id string, links []types.Link) {
+		for _, link := range links {
+			if err := types.ValidateLinkURL(link.URL); err != nil {
+				add(id, fmt.Sprintf("%s: %v", id, err))
+			}
+			if !types.IsValidLinkKind(link.Kind) {
+				add(id, fmt.Sprintf("%s links to %s with invalid kind %q", id, link.URL, link.Kind))
+			}
+		}
+	}
+	checkComments := func(id string, comments []types.Comment) {
+		for i, comment := range comments {
+			if strings.TrimSpace(comment.Body) == "" {
+				add(id, fmt.Sprintf("%s: comment %d is empty", id, i+1))
+			}
+		}
+	}
+	for _, milestone := range roadmap.Milestones {
+		checkLinks(milestone.ID, milestone.Links)
+		checkComments(milestone.ID, milestone.Comments)
+	}
+	for _, task := range roadmap.Tasks {
+		checkLinks(task.ID, task.Links)
+		checkComments(task.ID, task.Comments)
+	}
+
+	// Metadata keys with a custom field definition must match its type
+	checkCustomFields := func(id string, metadata map[string]string) {
+		keys := make([]string, 0, len(metadata))
+		for key := range metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if field := types.FindCustomField(key); field != nil {
+				if err := field.Check(metadata[key]); err != nil {
+					add(id, fmt.Sprintf("%s: %v", id, err))
+				}
+			}
+		}
+	}
+	for _, milestone := range roadmap.Milestones {
+		checkCustomFields(milestone.ID, milestone.Metadata)
+	}
+	for _, task := range roadmap.Tasks {
+		checkCustomFields(task.ID, task.Metadata)
+	}
+
+	// Gates need a name unique to their milestone and a sensible threshold
+	for _, milestone := range roadmap.Milestones {
+		names := make(map[string]bool)
+		for _, gate := range milestone.Gates {
+			if err := gate.Validate(); err != nil {
+				add(milestone.ID, fmt.Sprintf("%s: %v", milestone.ID, err))
+			}
+			if gate.Name != "" && names[gate.Name] {
+				add(milestone.ID, fmt.Sprintf("%s has more than one gate named %q", milestone.ID, gate.Name))
+			}
+			names[gate.Name] = true
+		}
+	}
+
+	// Dependencies must point at existing items and must not loop
+	checkDependencies := func(id string, deps []string) {
+		for _, dep := range deps {
+			if !milestoneIDs[dep] && !taskIDs[dep] {
+				add(id, fmt.Sprintf("%s depends on non-existent item: %s", id, dep))
+			}
+		}
+	}
+	for _, milestone := range roadmap.Milestones {
+		checkDependencies(milestone.ID, milestone.DependsOn)
+	}
+	for _, task := range roadmap.Tasks {
+		checkDependencies(task.ID, task.DependsOn)
+	}
+	for _, cycle := range FindDependencyCycles(roadmap) {
+		add(cycle[0], fmt.Sprintf("dependency cycle: %s", strings.Join(cycle, " → ")))
+	}
+
+	// Cycles need unique names, sane dates, existing items and at most one
+	// running at a time
+	cycleNames := make(map[string]bool)
+	active := 0
+	planned := make(map[string]string)
+	for _, cycle := range roadmap.Cycles {
+		if cycle.Name == "" {
+			continue
+		}
+		if cycleNames[cycle.Name] {
+			add(cycle.Name, fmt.Sprintf("duplicate cycle name: %s", cycle.Name))
+		}
+		cycleNames[cycle.Name] = true
+
+		if cycle.Status == types.CycleActive {
+			active++
+			if active == 2 {
+				add(cycle.Name, fmt.Sprintf("cycle %s is active while another cycle is too", cycle.Name))
+			}
+		}
+		start, startErr := cycle.Start.Time()
+		end, endErr := cycle.End.Time()
+		if startErr == nil && endErr == nil && start.After(end) {
+			add(cycle.Name, fmt.Sprintf("cycle %s starts on %s, after it ends on %s", cycle.Name, cycle.Start, cycle.End))
+		}
+		if cycle.Capacity < 0 {
+			add(cycle.Name, fmt.Sprintf("cycle %s has negative capacity", cycle.Name))
+		}
+		for _, id := range cycle.Items {
+			if !milestoneIDs[id] && !taskIDs[id] {
+				add(cycle.Name, fmt.Sprintf("cycle %s plans non-existent item: %s", cycle.Name, id))
+			}
+			if cycle.Status == types.CycleClosed {
+				continue
+			}
+			if other, ok := planned[id]; ok {
+				add(cycle.Name, fmt.Sprintf("%s is planned into both %s and %s", id, other, cycle.Name))
+			}
+			planned[id] = cycle.Name
+		}
+	}
+
+	// Release names are unique, and once releases are declared milestones
+	// may only ship in one of them
+	releaseNames := make(map[string]bool)
+	for _, release := range roadmap.Releases {
+		if release.Name == "" {
+			continue
+		}
+		if releaseNames[release.Name] {
+			add(release.Name, fmt.Sprintf("duplicate release name: %s", release.Name))
+		}
+		releaseNames[release.Name] = true
+	}
+	if len(releaseNames) > 0 {
+		for _, milestone := range roadmap.Milestones {
+			if milestone.Version != "" && !releaseNames[milestone.Version] {
+				add(milestone.ID, fmt.Sprintf("milestone %s is assigned to unknown release: %s", milestone.ID, milestone.Version))
+			}
+		}
+	}
+
+	// Items must start before they end and finish within their parent
+	checkDates := func(id, parentID string, details *types.Details) {
+		end, err := details.End().Time()
+		if err != nil {
+			return
+		}
+		if start, err := details.Start.Time(); err == nil && start.After(end) {
+			add(id, fmt.Sprintf("%s starts on %s, after it ends on %s", id, details.Start, details.End()))
+		}
+		if ancestor, ancestorEnd := datedAncestor(roadmap, parentID); ancestor != "" {
+			if limit, err := ancestorEnd.Time(); err == nil && end.After(limit) {
+				add(id, fmt.Sprintf("%s ends on %s, after its parent %s ends on %s", id, details.End(), ancestor, ancestorEnd))
+			}
+		}
+	}
+	for i := range roadmap.Milestones {
+		checkDates(roadmap.Milestones[i].ID, "", &roadmap.Milestones[i].Details)
+	}
+	for i := range roadmap.Tasks {
+		checkDates(roadmap.Tasks[i].ID, roadmap.Tasks[i].ParentID, &roadmap.Tasks[i].Details)
+	}
+
+	return violations
+}
+
+// datedAncestor walks up from id to the nearest item with an end date
+func datedAncestor(roadmap *types.Roadmap, id string) (string, types.Date) {
+	seen := make(map[string]bool)
+	for id != "" && !seen[id] {
+		seen[id] = true
+		if milestone := roadmap.GetMilestoneByID(id); milestone != nil {
+			return id, milestone.End()
+		}
+		task := roadmap.GetTaskByID(id)
+		if task == nil {
+			return "", ""
+		}
+		if task.End().IsSet() {
+			return id, task.End()
+		}
+		id = task.ParentID
+	}
+	return "", ""
+}
+
+// itemLines maps each item ID in a document to the line it starts on
+func itemLines(doc *yaml.Node) map[string]int {
+	lines := make(map[string]int)
+
+	root := doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	for _, section := range []string{types.SchemaFamilies, types.SchemaMilestones, types.SchemaTasks, types.SchemaCycles, types.SchemaReleases} {
+		items := mappingValue(root, section)
+		if items == nil || items.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, item := range items.Content {
+			if id := itemKey(item); id != nil {
+				if _, seen := lines[id.Value]; !seen {
+					lines[id.Value] = item.Line
+				}
+			}
+		}
+	}
+
+	return lines
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// unknownMessage points keys from older layouts at spiral migrate
+func unknownMessage(message, key string) string {
+	if hint, ok := legacyFields[key]; ok {
+		return fmt.Sprintf("%s (%s; run spiral migrate)", message, hint)
+	}
+	return message
+}
+
+// itemKey returns the node identifying an item: its id, code for families or
+// name for cycles
+func itemKey(item *yaml.Node) *yaml.Node {
+	for _, key := range []string{"id", "code", "name"} {
+		if value := mappingValue(item, key); value != nil {
+			return value
+		}
+	}
+	return nil
+}
+
+// itemLabel names an item for messages ("milestone D3", "task at index 2")
+func itemLabel(section string, item *yaml.Node, index int) string {
+	kind := strings.TrimSuffix(section, "s")
+	if section == types.SchemaFamilies {
+		kind = "family"
+	}
+	if id := itemKey(item); id != nil && id.Value != "" {
+		return fmt.Sprintf("%s %s", kind, id.Value)
+	}
+	return fmt.Sprintf("%s at index %d", kind, index)
+}
+
+// isNull reports whether a node is an explicit or implicit YAML null
+func isNull(node *yaml.Node) bool {
+	return node == nil || (node.Kind == yaml.ScalarNode && node.Tag == "!!null")
+}
+
+// isEmpty reports whether a node carries no value
+func isEmpty(node *yaml.Node) bool {
+	if isNull(node) {
+		return true
+	}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Value == ""
+	case yaml.SequenceNode, yaml.MappingNode:
+		return len(node.Content) == 0
+	}
+	return false
+}
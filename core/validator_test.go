@@ -0,0 +1,102 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateRoadmapFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string // "line: message" of each violation, in order
+	}{
+		{
+			name: "valid",
+			content: `schema_version: 2
+milestones:
+  - id: D1
+    family: D
+    title: M
+    priority: high
+    status: planned
+tasks:
+  - id: D1.1
+    parent_id: D1
+    title: T
+    status: in-progress
+`,
+		},
+		{
+			name: "invalid enum value",
+			content: `milestones:
+  - id: D1
+    family: D
+    title: M
+    priority: urgent
+`,
+			want: []string{`5: milestone D1: priority has invalid value "urgent" (allowed: low, medium, high, critical)`},
+		},
+		{
+			name: "missing required field",
+			content: `milestones:
+  - id: D1
+    family: D
+`,
+			want: []string{"2: milestone D1: missing required field title"},
+		},
+		{
+			name: "unknown field",
+			content: `milestones:
+  - id: D1
+    family: D
+    title: M
+    colour: red
+`,
+			want: []string{"5: milestone D1: unknown field colour"},
+		},
+		{
+			name: "duplicate ID",
+			content: `milestones:
+  - id: D1
+    family: D
+    title: M
+  - id: D1
+    family: D
+    title: N
+`,
+			want: []string{"2: duplicate milestone ID: D1"},
+		},
+		{
+			name: "missing parent",
+			content: `tasks:
+  - id: D9.1
+    parent_id: D9
+    title: T
+`,
+			want: []string{"2: task D9.1 references non-existent parent: D9"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), RoadmapFile)
+			writeFile(t, path, tt.content)
+
+			violations, err := ValidateRoadmapFile(path)
+			if err != nil {
+				t.Fatalf("ValidateRoadmapFile: %v", err)
+			}
+
+			got := make([]string, len(violations))
+			for i, v := range violations {
+				got[i] = fmt.Sprintf("%d: %s", v.Line, v.Message)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("violations:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
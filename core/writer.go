@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
+	"time"
 
+	"github.com/thusai/spiral/config"
 	"github.com/thusai/spiral/types"
-	"gopkg.in/yaml.v3"
 )
 
 // SaveRoadmap (no file path) - convenience function using default file
@@ -19,28 +21,86 @@ func SaveRoadmap(roadmap *types.Roadmap) error {
 	return SaveRoadmapToFile(roadmap, filePath)
 }
 
-// SaveRoadmapToFile atomically writes a roadmap to a YAML file
+// SaveRoadmapToFile atomically writes a roadmap to a YAML file, or to the
+// files of a split roadmap when filePath is a directory. It holds the lock
+// while writing and refuses with a *ConflictError if a file changed since
+// the roadmap was loaded.
 func SaveRoadmapToFile(roadmap *types.Roadmap, filePath string) error {
+	return saveRoadmap(roadmap, filePath, func(roadmap *types.Roadmap) error {
+		if IsRoadmapDir(filePath) {
+			// Write each item back to the file it came from
+			return saveRoadmapDir(roadmap, filePath)
+		}
+
+		// Marshal to YAML, patching the file it was loaded from if any
+		data, err := marshalRoadmap(roadmap)
+		if err != nil {
+			return err
+		}
+
+		// Never overwrite an edit made since the roadmap was loaded
+		if err := checkUnchanged(roadmap, filePath); err != nil {
+			return err
+		}
+
+		// Use atomic write (temp file + rename)
+		if err := atomicWriteFile(filePath, data); err != nil {
+			return err
+		}
+		roadmap.Source = data
+		return nil
+	})
+}
+
+// saveRoadmap runs what every store does around writing a roadmap: taking
+// the lock on path, rolling up statuses, validating and stamping the schema
+// version before write, and logging the changes after it
+func saveRoadmap(roadmap *types.Roadmap, path string, write func(*types.Roadmap) error) error {
+	unlock, err := lockRoadmap(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Keep parent statuses in step with their children
+	at := now()
+	for _, change := range RollupStatuses(roadmap) {
+		if details := roadmap.DetailsOf(change.ID); details != nil {
+			stampStatus(details, change.From, change.To, at)
+			details.UpdatedAt = &at
+		}
+		recordChange(roadmap, change.ID, "status", change.From, change.To, "rolled up from children")
+	}
+
 	// Validate before saving
 	if err := ValidateRoadmap(roadmap); err != nil {
 		return fmt.Errorf("roadmap validation failed: %w", err)
 	}
 
-	// Marshal to YAML
-	data, err := yaml.Marshal(roadmap)
-	if err != nil {
-		return fmt.Errorf("failed to marshal roadmap to YAML: %w", err)
+	// Stamp the schema version so older binaries can detect newer files
+	if roadmap.SchemaVersion == 0 {
+		roadmap.SchemaVersion = types.CurrentSchemaVersion
 	}
 
-	// Use atomic write (temp file + rename)
-	return atomicWriteFile(filePath, data)
+	if err := write(roadmap); err != nil {
+		return err
+	}
+
+	// Log what changed now that it's on disk
+	if err := config.AppendHistory(roadmap.Changes); err != nil {
+		return fmt.Errorf("roadmap saved but history not recorded: %w", err)
+	}
+	roadmap.Changes = nil
+	return nil
 }
 
 // AddMilestone adds a new milestone to the roadmap
 func AddMilestone(roadmap *types.Roadmap, milestone types.Milestone) error {
+	milestone.ID = types.NormalizeID(milestone.ID)
+
 	// Check for duplicate ID
 	for _, existing := range roadmap.Milestones {
-		if existing.ID == milestone.ID {
+		if types.SameID(existing.ID, milestone.ID) {
 			return fmt.Errorf("milestone with ID %s already exists", milestone.ID)
 		}
 	}
@@ -54,24 +114,33 @@ func AddMilestone(roadmap *types.Roadmap, milestone types.Milestone) error {
 	}
 
 	// Validate enum fields
-	if milestone.Priority != "" && !types.IsValidPriority(milestone.Priority) {
-		return fmt.Errorf("invalid priority: %s", milestone.Priority)
+	enums := []struct{ field, value string }{
+		{"priority", milestone.Priority},
+		{"status", milestone.Status},
+		{"cycle_status", milestone.CycleStatus},
+		{"release_status", milestone.ReleaseStatus},
 	}
-
-	if milestone.CycleStatus != "" && !types.IsValidCycleStatus(milestone.CycleStatus) {
-		return fmt.Errorf("invalid cycle_status: %s", milestone.CycleStatus)
+	for _, enum := range enums {
+		if err := CheckField(types.SchemaMilestones, enum.field, enum.value); err != nil {
+			return err
+		}
 	}
 
 	// Add milestone
+	stampCreated(&milestone.Details, milestone.Status, now())
 	roadmap.Milestones = append(roadmap.Milestones, milestone)
+	recordChange(roadmap, milestone.ID, "created", "", milestone.Title, "")
 	return nil
 }
 
 // AddTask adds a new task to the roadmap
 func AddTask(roadmap *types.Roadmap, task types.Task) error {
+	task.ID = types.NormalizeID(task.ID)
+	task.ParentID = types.NormalizeID(task.ParentID)
+
 	// Check for duplicate ID
 	for _, existing := range roadmap.Tasks {
-		if existing.ID == task.ID {
+		if types.SameID(existing.ID, task.ID) {
 			return fmt.Errorf("task with ID %s already exists", task.ID)
 		}
 	}
@@ -108,12 +177,17 @@ func AddTask(roadmap *types.Roadmap, task types.Task) error {
 	}
 
 	// Validate enum fields
-	if task.Status != "" && !types.IsValidTaskStatus(task.Status) {
-		return fmt.Errorf("invalid status: %s", task.Status)
+	if err := CheckField(types.SchemaTasks, "status", task.Status); err != nil {
+		return err
+	}
+	if err := CheckField(types.SchemaTasks, "priority", task.Priority); err != nil {
+		return err
 	}
 
 	// Add task
+	stampCreated(&task.Details, task.Status, now())
 	roadmap.Tasks = append(roadmap.Tasks, task)
+	recordChange(roadmap, task.ID, "created", "", task.Title, "")
 	return nil
 }
 
@@ -131,6 +205,21 @@ func UpdateMilestone(roadmap *types.Roadmap, id string, updates map[string]inter
 		return fmt.Errorf("milestone %s not found", id)
 	}
 
+	// Gates run on the way to the gated status before anything else
+	// changes. When they fail, their results stay on the milestone but none
+	// of the updates are applied.
+	if status, ok := updates["status"].(string); ok && status != milestone.Status &&
+		types.WorkflowFor(types.WorkflowStatus).IsGated(status) && len(milestone.Gates) > 0 {
+		if err := CheckTransition(types.SchemaMilestones, types.WorkflowStatus, milestone.Status, status); err != nil {
+			return err
+		}
+		if err := RunGates(roadmap, id); err != nil {
+			return err
+		}
+	}
+
+	before := *milestone
+
 	// Apply updates
 	for field, value := range updates {
 		switch field {
@@ -145,24 +234,78 @@ func UpdateMilestone(roadmap *types.Roadmap, id string, updates map[string]inter
 
 		case "priority":
 			if str, ok := value.(string); ok {
-				if str != "" && !types.IsValidPriority(str) {
-					return fmt.Errorf("invalid priority: %s", str)
+				if err := CheckField(types.SchemaMilestones, "priority", str); err != nil {
+					return err
 				}
 				milestone.Priority = str
 			}
 		case "cycle_status":
 			if str, ok := value.(string); ok {
-				if str != "" && !types.IsValidCycleStatus(str) {
-					return fmt.Errorf("invalid cycle_status: %s", str)
+				if err := CheckTransition(types.SchemaMilestones, types.WorkflowCycleStatus, milestone.CycleStatus, str); err != nil {
+					return err
 				}
 				milestone.CycleStatus = str
 			}
-
+		case "status":
+			if str, ok := value.(string); ok {
+				if err := CheckTransition(types.SchemaMilestones, types.WorkflowStatus, milestone.Status, str); err != nil {
+					return err
+				}
+				milestone.Status = str
+			}
+		case "release_status":
+			if str, ok := value.(string); ok {
+				if err := CheckTransition(types.SchemaMilestones, types.WorkflowReleaseStatus, milestone.ReleaseStatus, str); err != nil {
+					return err
+				}
+				milestone.ReleaseStatus = str
+			}
+		case "week":
+			if str, ok := value.(string); ok {
+				milestone.Week = str
+			}
+		case "version":
+			if str, ok := value.(string); ok {
+				milestone.Version = str
+			}
+		case "success_gate":
+			if str, ok := value.(string); ok {
+				milestone.SuccessGate = str
+			}
+		case "notes":
+			if str, ok := value.(string); ok {
+				milestone.Notes = str
+			}
+		case "size", "points":
+			if err := updateEstimate(&milestone.Details, field, value); err != nil {
+				return err
+			}
+		case "start", "due", "target":
+			if err := updateDate(&milestone.Details, field, value); err != nil {
+				return err
+			}
+		case "status_locked":
+			if locked, ok := value.(bool); ok {
+				milestone.StatusLocked = locked
+			}
+		case "assignees":
+			if list, ok := value.([]string); ok {
+				milestone.Assignees = list
+			}
 		default:
 			return fmt.Errorf("unknown field: %s", field)
 		}
 	}
 
+	if !reflect.DeepEqual(before, *milestone) {
+		at := now()
+		if milestone.Status != before.Status {
+			stampStatus(&milestone.Details, before.Status, milestone.Status, at)
+		}
+		milestone.UpdatedAt = &at
+		recordDiff(roadmap, id, before, *milestone)
+	}
+
 	return nil
 }
 
@@ -180,6 +323,8 @@ func UpdateTask(roadmap *types.Roadmap, id string, updates map[string]interface{
 		return fmt.Errorf("task %s not found", id)
 	}
 
+	before := *task
+
 	// Apply updates
 	for field, value := range updates {
 		switch field {
@@ -189,20 +334,123 @@ func UpdateTask(roadmap *types.Roadmap, id string, updates map[string]interface{
 			}
 		case "status":
 			if str, ok := value.(string); ok {
-				if str != "" && !types.IsValidTaskStatus(str) {
-					return fmt.Errorf("invalid status: %s", str)
+				if err := CheckTransition(types.SchemaTasks, types.WorkflowStatus, task.Status, str); err != nil {
+					return err
 				}
 				task.Status = str
 			}
+		case "priority":
+			if str, ok := value.(string); ok {
+				if err := CheckField(types.SchemaTasks, "priority", str); err != nil {
+					return err
+				}
+				task.Priority = str
+			}
 		case "notes":
 			if str, ok := value.(string); ok {
 				task.Notes = str
 			}
+		case "size", "points":
+			if err := updateEstimate(&task.Details, field, value); err != nil {
+				return err
+			}
+		case "start", "due", "target":
+			if err := updateDate(&task.Details, field, value); err != nil {
+				return err
+			}
+		case "status_locked":
+			if locked, ok := value.(bool); ok {
+				task.StatusLocked = locked
+			}
+		case "assignees":
+			if list, ok := value.([]string); ok {
+				task.Assignees = list
+			}
 		default:
 			return fmt.Errorf("unknown field: %s", field)
 		}
 	}
 
+	if !reflect.DeepEqual(before, *task) {
+		at := now()
+		if task.Status != before.Status {
+			stampStatus(&task.Details, before.Status, task.Status, at)
+		}
+		task.UpdatedAt = &at
+		recordDiff(roadmap, id, before, *task)
+	}
+
+	return nil
+}
+
+// CheckTransition verifies that a status field may change from one value to
+// another: the new value must pass the schema and, for fields with a
+// workflow, the move must be one of the workflow's transitions
+func CheckTransition(section, field, from, to string) error {
+	if err := CheckField(section, field, to); err != nil {
+		return err
+	}
+	if workflow := types.WorkflowFor(field); workflow != nil {
+		if err := workflow.CheckTransition(from, to); err != nil {
+			return fmt.Errorf("invalid %s change: %w", field, err)
+		}
+	}
+	return nil
+}
+
+// updateEstimate applies a size or points update to an item's details
+func updateEstimate(details *types.Details, field string, value interface{}) error {
+	switch field {
+	case "size":
+		if str, ok := value.(string); ok {
+			if str != "" && !types.IsValidSize(str) {
+				return fmt.Errorf("invalid size: %s", str)
+			}
+			details.Size = str
+		}
+	case "points":
+		var points float64
+		switch v := value.(type) {
+		case float64:
+			points = v
+		case int:
+			points = float64(v)
+		default:
+			return fmt.Errorf("points must be a number")
+		}
+		if points < 0 {
+			return fmt.Errorf("points cannot be negative: %v", points)
+		}
+		details.Points = points
+	}
+	return nil
+}
+
+// updateDate applies a start, due or target update to an item's details.
+// Values may be a types.Date, an ISO date string or a relative expression.
+func updateDate(details *types.Details, field string, value interface{}) error {
+	var date types.Date
+	switch v := value.(type) {
+	case types.Date:
+		date = v
+	case string:
+		parsed, err := types.ParseDate(v, time.Now())
+		if err != nil {
+			return fmt.Errorf("invalid %s date: %w", field, err)
+		}
+		date = parsed
+	default:
+		return fmt.Errorf("%s must be a date", field)
+	}
+
+	switch field {
+	case "start":
+		details.Start = date
+	case "due":
+		details.Due = date
+	case "target":
+		details.Target = date
+	}
 	return nil
 }
 
@@ -29,11 +29,28 @@ func main() {
 			cmd.ShowCommand(),
 			cmd.AddCommand(),
 			cmd.ContextCommand(),
+			cmd.FamilyCommand(),
+			cmd.DepCommand(),
+			cmd.LabelCommand(),
+			cmd.LinkCommand(),
+			cmd.CommentCommand(),
+			cmd.StatusCommand(),
+			cmd.GateCommand(),
+			cmd.HistoryCommand(),
+			cmd.CycleCommand(),
+			cmd.ReleaseCommand(),
+			cmd.MineCommand(),
+			cmd.WorkloadCommand(),
+			cmd.ExportCommand(),
 			cmd.CommitCommand(),
+			cmd.ValidateCommand(),
+			cmd.MigrateCommand(),
+			cmd.LayoutCommand(),
 			cmd.ProjectsCommand(),
 			cmd.InitCommand(),
 			cmd.UseCommand(),
 		},
+		Before: cmd.Setup,
 		Action: func(c *cli.Context) error {
 			// Default action when no subcommand is provided
 			return cmd.DefaultAction(c)
@@ -0,0 +1,11 @@
+package types
+
+import "time"
+
+// Comment is one entry in the discussion on an item. Comments are only ever
+// appended, so earlier context survives later edits to the notes.
+type Comment struct {
+	Author string    `yaml:"author" json:"author"`
+	At     time.Time `yaml:"at" json:"at"`
+	Body   string    `yaml:"body" json:"body"`
+}
@@ -0,0 +1,82 @@
+package types
+
+import "time"
+
+// Cycle statuses
+const (
+	CyclePlanned = "planned"
+	CycleActive  = "active"
+	CycleClosed  = "closed"
+)
+
+// Cycle is a named, time-boxed batch of work with a capacity in points
+type Cycle struct {
+	Name     string        `yaml:"name" json:"name"`
+	Status   string        `yaml:"status" json:"status"`
+	Start    Date          `yaml:"start,omitempty" json:"start,omitempty"`
+	End      Date          `yaml:"end,omitempty" json:"end,omitempty"`
+	Capacity float64       `yaml:"capacity,omitempty" json:"capacity,omitempty"`
+	Items    []string      `yaml:"items,omitempty" json:"items,omitempty"`
+	Summary  *CycleSummary `yaml:"summary,omitempty" json:"summary,omitempty"`
+}
+
+// CycleSummary is written when a cycle closes
+type CycleSummary struct {
+	ClosedAt        time.Time `yaml:"closed_at" json:"closed_at"`
+	PlannedPoints   float64   `yaml:"planned_points" json:"planned_points"`
+	CompletedPoints float64   `yaml:"completed_points" json:"completed_points"`
+	Finished        []string  `yaml:"finished,omitempty" json:"finished,omitempty"`
+	CarriedOver     []string  `yaml:"carried_over,omitempty" json:"carried_over,omitempty"`
+	CarriedTo       string    `yaml:"carried_to,omitempty" json:"carried_to,omitempty"`
+}
+
+// HasItem reports whether an item is planned into the cycle
+func (c *Cycle) HasItem(id string) bool {
+	for _, item := range c.Items {
+		if item == id {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCycle finds a cycle by name
+func (r *Roadmap) GetCycle(name string) *Cycle {
+	for i := range r.Cycles {
+		if r.Cycles[i].Name == name {
+			return &r.Cycles[i]
+		}
+	}
+	return nil
+}
+
+// ActiveCycle returns the running cycle, or nil
+func (r *Roadmap) ActiveCycle() *Cycle {
+	for i := range r.Cycles {
+		if r.Cycles[i].Status == CycleActive {
+			return &r.Cycles[i]
+		}
+	}
+	return nil
+}
+
+// NextPlannedCycle returns the first cycle that hasn't started yet, or nil
+func (r *Roadmap) NextPlannedCycle() *Cycle {
+	for i := range r.Cycles {
+		if r.Cycles[i].Status == CyclePlanned {
+			return &r.Cycles[i]
+		}
+	}
+	return nil
+}
+
+// CycleOf returns the open (planned or active) cycle an item is planned
+// into, or nil
+func (r *Roadmap) CycleOf(id string) *Cycle {
+	for i := range r.Cycles {
+		if r.Cycles[i].Status != CycleClosed && r.Cycles[i].HasItem(id) {
+			return &r.Cycles[i]
+		}
+	}
+	return nil
+}
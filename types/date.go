@@ -0,0 +1,148 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DateLayout is how dates are written in roadmap files
+const DateLayout = "2006-01-02"
+
+// Date is a calendar day written as 2006-01-02. The empty Date means unset.
+type Date string
+
+// Time returns the date as midnight UTC
+func (d Date) Time() (time.Time, error) {
+	return time.Parse(DateLayout, string(d))
+}
+
+// IsSet reports whether the date has a value
+func (d Date) IsSet() bool {
+	return d != ""
+}
+
+// DaysFrom returns the number of whole days from day to d (negative if d is
+// earlier)
+func (d Date) DaysFrom(day time.Time) (int, error) {
+	t, err := d.Time()
+	if err != nil {
+		return 0, err
+	}
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	return int(t.Sub(from).Hours() / 24), nil
+}
+
+// MarshalYAML writes dates unquoted, like hand-written ones
+func (d Date) MarshalYAML() (interface{}, error) {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!timestamp", Value: string(d)}, nil
+}
+
+// DateOf returns the Date for a point in time
+func DateOf(t time.Time) Date {
+	return Date(t.Format(DateLayout))
+}
+
+// ParseDate parses an ISO date or a relative expression, relative to now:
+//
+//	2024-06-30, today, tomorrow, yesterday
+//	friday, next friday    the first Friday after today
+//	next week, next month  the same day a week or month from today
+//	in 3 days, in 2 weeks  offsets in days, weeks or months
+//	+3d, +2w, +1m          the same offsets, abbreviated
+func ParseDate(expr string, now time.Time) (Date, error) {
+	expr = strings.ToLower(strings.TrimSpace(expr))
+	if expr == "" {
+		return "", nil
+	}
+
+	if t, err := time.Parse(DateLayout, expr); err == nil {
+		return DateOf(t), nil
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch expr {
+	case "today":
+		return DateOf(today), nil
+	case "tomorrow":
+		return DateOf(today.AddDate(0, 0, 1)), nil
+	case "yesterday":
+		return DateOf(today.AddDate(0, 0, -1)), nil
+	case "next week":
+		return DateOf(today.AddDate(0, 0, 7)), nil
+	case "next month":
+		return DateOf(today.AddDate(0, 1, 0)), nil
+	}
+
+	// Weekdays: "friday" and "next friday" both mean the first one after today
+	if weekday, ok := parseWeekday(strings.TrimPrefix(expr, "next ")); ok {
+		days := (int(weekday) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return DateOf(today.AddDate(0, 0, days)), nil
+	}
+
+	// Offsets: "in 3 days", "+3d"
+	if n, unit, ok := parseOffset(expr); ok {
+		switch unit {
+		case "d":
+			return DateOf(today.AddDate(0, 0, n)), nil
+		case "w":
+			return DateOf(today.AddDate(0, 0, 7*n)), nil
+		case "m":
+			return DateOf(today.AddDate(0, n, 0)), nil
+		}
+	}
+
+	return "", fmt.Errorf("unrecognised date: %q (use YYYY-MM-DD, today, next friday, in 3 days, +2w)", expr)
+}
+
+// parseWeekday recognises full and three-letter weekday names
+func parseWeekday(s string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if s == name || s == name[:3] {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// parseOffset recognises "in N days|weeks|months" and "+Nd|w|m". N is a
+// plain count: "+-3d" and "in -3 days" are not dates in the future.
+func parseOffset(s string) (int, string, bool) {
+	if strings.HasPrefix(s, "+") && len(s) > 2 {
+		n, ok := parseCount(s[1 : len(s)-1])
+		return n, s[len(s)-1:], ok
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) != 3 || fields[0] != "in" {
+		return 0, "", false
+	}
+	n, ok := parseCount(fields[1])
+	if !ok {
+		return 0, "", false
+	}
+	unit := strings.TrimSuffix(fields[2], "s")
+	switch unit {
+	case "day":
+		return n, "d", true
+	case "week":
+		return n, "w", true
+	case "month":
+		return n, "m", true
+	}
+	return 0, "", false
+}
+
+// parseCount parses an unsigned decimal count
+func parseCount(s string) (int, bool) {
+	n, err := strconv.ParseUint(s, 10, 31)
+	return int(n), err == nil
+}
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	// A Wednesday, late in the day so dates must not drift into tomorrow
+	now := time.Date(2024, time.June, 12, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr    string
+		want    Date
+		wantErr bool
+	}{
+		{expr: "", want: ""},
+		{expr: "2024-06-30", want: "2024-06-30"},
+		{expr: " 2024-06-30 ", want: "2024-06-30"},
+		{expr: "today", want: "2024-06-12"},
+		{expr: "Tomorrow", want: "2024-06-13"},
+		{expr: "yesterday", want: "2024-06-11"},
+		{expr: "next week", want: "2024-06-19"},
+		{expr: "next month", want: "2024-07-12"},
+		{expr: "friday", want: "2024-06-14"},
+		{expr: "next fri", want: "2024-06-14"},
+		{expr: "wednesday", want: "2024-06-19"},
+		{expr: "in 3 days", want: "2024-06-15"},
+		{expr: "in 1 day", want: "2024-06-13"},
+		{expr: "in 2 weeks", want: "2024-06-26"},
+		{expr: "in 1 month", want: "2024-07-12"},
+		{expr: "+0d", want: "2024-06-12"},
+		{expr: "+3d", want: "2024-06-15"},
+		{expr: "+2w", want: "2024-06-26"},
+		{expr: "+1m", want: "2024-07-12"},
+		{expr: "+-3d", wantErr: true},
+		{expr: "++3d", wantErr: true},
+		{expr: "in -3 days", wantErr: true},
+		{expr: "in +3 days", wantErr: true},
+		{expr: "+3y", wantErr: true},
+		{expr: "+d", wantErr: true},
+		{expr: "in 3 years", wantErr: true},
+		{expr: "in three days", wantErr: true},
+		{expr: "2024-02-30", wantErr: true},
+		{expr: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParseDate(tt.expr, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseDate(%q) = %q, want an error", tt.expr, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDate(%q): %v", tt.expr, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDate(%q) = %q, want %q", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDaysFrom(t *testing.T) {
+	now := time.Date(2024, time.June, 12, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		date    Date
+		want    int
+		wantErr bool
+	}{
+		{date: "2024-06-12", want: 0},
+		{date: "2024-06-13", want: 1},
+		{date: "2024-06-05", want: -7},
+		{date: "2025-06-12", want: 365},
+		{date: "someday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.date), func(t *testing.T) {
+			got, err := tt.date.DaysFrom(now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DaysFrom error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("%s.DaysFrom = %d, want %d", tt.date, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Custom field types
+const (
+	CustomString = "string"
+	CustomInt    = "int"
+	CustomDate   = "date"
+	CustomEnum   = "enum"
+	CustomBool   = "bool"
+)
+
+// CustomField gives a metadata key a type. Items store custom values as
+// strings in their metadata map.
+type CustomField struct {
+	Name   string   `json:"name"`
+	Type   string   `json:"type"`
+	Values []string `json:"values,omitempty"`
+}
+
+// CustomFields are the project's custom field definitions
+var CustomFields []CustomField
+
+// FindCustomField returns the definition for a metadata key, or nil
+func FindCustomField(name string) *CustomField {
+	for i := range CustomFields {
+		if CustomFields[i].Name == name {
+			return &CustomFields[i]
+		}
+	}
+	return nil
+}
+
+// ValidateCustomFields checks a set of custom field definitions
+func ValidateCustomFields(fields []CustomField) error {
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		if field.Name == "" {
+			return fmt.Errorf("custom field without a name")
+		}
+		if seen[field.Name] {
+			return fmt.Errorf("custom field %s is defined twice", field.Name)
+		}
+		seen[field.Name] = true
+
+		switch field.Type {
+		case CustomString, CustomInt, CustomDate, CustomBool:
+		case CustomEnum:
+			if len(field.Values) == 0 {
+				return fmt.Errorf("custom field %s is an enum but lists no values", field.Name)
+			}
+		default:
+			return fmt.Errorf("custom field %s has unknown type %q (use string, int, date, enum or bool)", field.Name, field.Type)
+		}
+	}
+	return nil
+}
+
+// Check reports whether a stored value is valid for the field
+func (f *CustomField) Check(value string) error {
+	switch f.Type {
+	case CustomInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%s must be an integer, got %q", f.Name, value)
+		}
+	case CustomDate:
+		if _, err := Date(value).Time(); err != nil {
+			return fmt.Errorf("%s must be a date (YYYY-MM-DD), got %q", f.Name, value)
+		}
+	case CustomBool:
+		if value != "true" && value != "false" {
+			return fmt.Errorf("%s must be true or false, got %q", f.Name, value)
+		}
+	case CustomEnum:
+		for _, allowed := range f.Values {
+			if value == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s has invalid value %q (allowed: %s)", f.Name, value, strings.Join(f.Values, ", "))
+	}
+	return nil
+}
+
+// Parse turns user input into the stored form of a value: relative dates are
+// resolved against now, booleans and integers are written canonically and
+// enum values are matched without regard to case.
+func (f *CustomField) Parse(input string, now time.Time) (string, error) {
+	input = strings.TrimSpace(input)
+
+	switch f.Type {
+	case CustomInt:
+		n, err := strconv.Atoi(input)
+		if err != nil {
+			return "", fmt.Errorf("%s must be an integer, got %q", f.Name, input)
+		}
+		return strconv.Itoa(n), nil
+	case CustomDate:
+		date, err := ParseDate(input, now)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", f.Name, err)
+		}
+		return string(date), nil
+	case CustomBool:
+		switch strings.ToLower(input) {
+		case "true", "yes", "y", "on", "1":
+			return "true", nil
+		case "false", "no", "n", "off", "0":
+			return "false", nil
+		}
+		return "", fmt.Errorf("%s must be true or false, got %q", f.Name, input)
+	case CustomEnum:
+		for _, allowed := range f.Values {
+			if strings.EqualFold(input, allowed) {
+				return allowed, nil
+			}
+		}
+		return "", fmt.Errorf("%s has invalid value %q (allowed: %s)", f.Name, input, strings.Join(f.Values, ", "))
+	}
+	return input, nil
+}
+
+// ParseFieldAssignment splits "key=value"
+func ParseFieldAssignment(assignment string) (string, string, error) {
+	key, value, ok := strings.Cut(assignment, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return "", "", fmt.Errorf("invalid field %q (use key=value)", assignment)
+	}
+	return key, value, nil
+}
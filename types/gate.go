@@ -0,0 +1,101 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// GatedStatus returns the status a milestone may only enter once its gates
+// pass, the first gated state of the status workflow, or "" if none is
+func GatedStatus() string {
+	return WorkflowFor(WorkflowStatus).Gated()
+}
+
+// Gate is a success condition a milestone must meet before it is done:
+// either a checklist item someone ticks off, or a shell command that has
+// to succeed and, with a coverage threshold, report at least that much
+type Gate struct {
+	Name     string      `yaml:"name" json:"name"`
+	Run      string      `yaml:"run,omitempty" json:"run,omitempty"`
+	Coverage float64     `yaml:"coverage,omitempty" json:"coverage,omitempty"`
+	Checked  bool        `yaml:"checked,omitempty" json:"checked,omitempty"`
+	Result   *GateResult `yaml:"result,omitempty" json:"result,omitempty"`
+}
+
+// GateResult records the last run of a command gate
+type GateResult struct {
+	At       time.Time `yaml:"at" json:"at"`
+	Passed   bool      `yaml:"passed" json:"passed"`
+	ExitCode int       `yaml:"exit_code,omitempty" json:"exit_code,omitempty"`
+	Coverage *float64  `yaml:"coverage,omitempty" json:"coverage,omitempty"`
+	Output   string    `yaml:"output,omitempty" json:"output,omitempty"`
+}
+
+// IsChecklist reports whether the gate is ticked off by hand
+func (g Gate) IsChecklist() bool {
+	return g.Run == ""
+}
+
+// Passed reports whether the gate is met as far as the roadmap knows: a
+// ticked checklist item or a command whose last run succeeded
+func (g Gate) Passed() bool {
+	if g.IsChecklist() {
+		return g.Checked
+	}
+	return g.Result != nil && g.Result.Passed
+}
+
+// Problem describes why a gate is not met, or "" if it is
+func (g Gate) Problem() string {
+	switch {
+	case g.Passed():
+		return ""
+	case g.IsChecklist():
+		return "not checked off"
+	case g.Result == nil:
+		return "not run yet"
+	case g.Result.ExitCode != 0:
+		return fmt.Sprintf("exited with status %d", g.Result.ExitCode)
+	case g.Result.Coverage == nil:
+		return fmt.Sprintf("no coverage figure in the output (needs %g%%)", g.Coverage)
+	default:
+		return fmt.Sprintf("coverage %g%% is below %g%%", *g.Result.Coverage, g.Coverage)
+	}
+}
+
+// Validate checks a gate definition
+func (g Gate) Validate() error {
+	if g.Name == "" {
+		return fmt.Errorf("gate name cannot be empty")
+	}
+	if g.Coverage < 0 || g.Coverage > 100 {
+		return fmt.Errorf("gate %q: coverage threshold must be between 0 and 100", g.Name)
+	}
+	if g.Coverage > 0 && g.IsChecklist() {
+		return fmt.Errorf("gate %q: a coverage threshold needs a command to run", g.Name)
+	}
+	if g.Checked && !g.IsChecklist() {
+		return fmt.Errorf("gate %q: only checklist gates can be checked off", g.Name)
+	}
+	return nil
+}
+
+// GatesPassed reports whether every gate is met
+func GatesPassed(gates []Gate) bool {
+	for _, gate := range gates {
+		if !gate.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Gate finds one of a milestone's gates by name
+func (m *Milestone) Gate(name string) *Gate {
+	for i := range m.Gates {
+		if m.Gates[i].Name == name {
+			return &m.Gates[i]
+		}
+	}
+	return nil
+}
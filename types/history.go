@@ -0,0 +1,14 @@
+package types
+
+import "time"
+
+// Change is one entry in the history log: a single field of an item changing
+type Change struct {
+	At     time.Time `json:"at"`
+	Who    string    `json:"who,omitempty"`
+	ItemID string    `json:"id"`
+	Field  string    `json:"field"`
+	Old    string    `json:"old,omitempty"`
+	New    string    `json:"new,omitempty"`
+	Note   string    `json:"note,omitempty"`
+}
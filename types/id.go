@@ -0,0 +1,228 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaxDepth is how far below a milestone items may nest unless the
+// project config says otherwise (D3.1.2.1.1 is depth 4)
+const DefaultMaxDepth = 4
+
+// MaxDepth is the deepest level new items may be created at (0=milestone)
+var MaxDepth = DefaultMaxDepth
+
+// IDGrammar describes how IDs are written in a project
+type IDGrammar struct {
+	// Separator goes between the family and the milestone number (OPS-12.3)
+	Separator string `json:"separator,omitempty"`
+	// Padding zero-pads milestone numbers to this width (D003.1)
+	Padding int `json:"padding,omitempty"`
+}
+
+// Grammar is the ID grammar used to parse and format IDs
+var Grammar IDGrammar
+
+// ID is a family-prefixed hierarchical ID such as D3, D3.1 or OPS-12.3
+type ID struct {
+	Family string // D, E, F (product families)
+	Path   []int  // milestone number followed by one number per level (D3.1.2 → [3 1 2])
+}
+
+// String returns the ID formatted with the active grammar (D3, D3.1, OPS-012.1)
+func (id ID) String() string {
+	parts := make([]string, len(id.Path))
+	for i, n := range id.Path {
+		parts[i] = strconv.Itoa(n)
+	}
+	if len(parts) > 0 && Grammar.Padding > 0 {
+		parts[0] = fmt.Sprintf("%0*d", Grammar.Padding, id.Path[0])
+	}
+	return id.Family + Grammar.Separator + strings.Join(parts, ".")
+}
+
+// CommitTag returns the ID formatted for git commit tags
+func (id ID) CommitTag() string {
+	return fmt.Sprintf("[%s]", id.String())
+}
+
+// Milestone returns the milestone number (D3.1.2 → 3)
+func (id ID) Milestone() int {
+	if len(id.Path) == 0 {
+		return 0
+	}
+	return id.Path[0]
+}
+
+// Number returns the last number in the path (D3.1.2 → 2)
+func (id ID) Number() int {
+	if len(id.Path) == 0 {
+		return 0
+	}
+	return id.Path[len(id.Path)-1]
+}
+
+// ParentID returns the parent ID (D3.1.1 → D3.1, D3.1 → D3, D3 → "")
+func (id ID) ParentID() string {
+	if len(id.Path) <= 1 {
+		return "" // Milestone has no parent
+	}
+	parent := ID{Family: id.Family, Path: id.Path[:len(id.Path)-1]}
+	return parent.String()
+}
+
+// Child returns the ID of the n-th child of this ID (D3.1, 2 → D3.1.2)
+func (id ID) Child(n int) ID {
+	path := make([]int, len(id.Path), len(id.Path)+1)
+	copy(path, id.Path)
+	return ID{Family: id.Family, Path: append(path, n)}
+}
+
+// IsChildOf reports whether id sits directly below parent
+func (id ID) IsChildOf(parent ID) bool {
+	if id.Family != parent.Family || len(id.Path) != len(parent.Path)+1 {
+		return false
+	}
+	for i, n := range parent.Path {
+		if id.Path[i] != n {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether two IDs have the same family and path
+func (id ID) Equal(other ID) bool {
+	if id.Family != other.Family || len(id.Path) != len(other.Path) {
+		return false
+	}
+	for i, n := range other.Path {
+		if id.Path[i] != n {
+			return false
+		}
+	}
+	return true
+}
+
+// IsDescendantOf reports whether id sits anywhere below ancestor
+func (id ID) IsDescendantOf(ancestor ID) bool {
+	if id.Family != ancestor.Family || len(id.Path) <= len(ancestor.Path) {
+		return false
+	}
+	for i, n := range ancestor.Path {
+		if id.Path[i] != n {
+			return false
+		}
+	}
+	return true
+}
+
+// Level returns the hierarchy level (0=milestone, 1=task, 2=subtask, ...)
+func (id ID) Level() int {
+	return len(id.Path) - 1
+}
+
+// ParseID parses a string into an ID structure. The family is every leading
+// letter (D3, API3); the grammar's separator and zero-padding are optional
+// when parsing, so IDs typed either way are accepted.
+func ParseID(idStr string) (*ID, error) {
+	if idStr == "" {
+		return nil, fmt.Errorf("empty ID")
+	}
+
+	// Extract family (leading alphabetic characters)
+	familyEnd := 0
+	for familyEnd < len(idStr) && isAlpha(idStr[familyEnd:familyEnd+1]) {
+		familyEnd++
+	}
+	if familyEnd == 0 {
+		return nil, fmt.Errorf("family must be alphabetic: %s", idStr)
+	}
+
+	family := idStr[:familyEnd]
+	rest := idStr[familyEnd:]
+	if Grammar.Separator != "" {
+		rest = strings.TrimPrefix(rest, Grammar.Separator)
+	}
+	if rest == "" {
+		return nil, fmt.Errorf("invalid ID format: %s", idStr)
+	}
+
+	// Parse numeric parts, one per level
+	parts := strings.Split(rest, ".")
+	id := &ID{Family: family, Path: make([]int, 0, len(parts))}
+
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || strings.HasPrefix(part, "+") {
+			if i == 0 {
+				return nil, fmt.Errorf("invalid milestone number: %s", part)
+			}
+			return nil, fmt.Errorf("invalid number at level %d: %s", i, part)
+		}
+		id.Path = append(id.Path, n)
+	}
+
+	return id, nil
+}
+
+// NormalizeID rewrites an ID in the active grammar, so OPS12.3 becomes
+// OPS-12.3 when the separator is "-". Unparseable IDs are returned unchanged
+// so callers can report them.
+func NormalizeID(idStr string) string {
+	id, err := ParseID(idStr)
+	if err != nil {
+		return idStr
+	}
+	return id.String()
+}
+
+// SameID reports whether two IDs name the same item however they are
+// written, so D3.1 matches D-003.1. Unparseable IDs must match exactly.
+func SameID(a, b string) bool {
+	idA, errA := ParseID(a)
+	idB, errB := ParseID(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return idA.Equal(*idB)
+}
+
+// CompareIDs orders IDs by family, then numerically level by level, so
+// D3.2 sorts before D3.10. Unparseable IDs fall back to string order.
+func CompareIDs(a, b string) int {
+	idA, errA := ParseID(a)
+	idB, errB := ParseID(b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+
+	if idA.Family != idB.Family {
+		return strings.Compare(idA.Family, idB.Family)
+	}
+	for i := 0; i < len(idA.Path) && i < len(idB.Path); i++ {
+		if idA.Path[i] != idB.Path[i] {
+			if idA.Path[i] < idB.Path[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(idA.Path) - len(idB.Path)
+}
+
+// IsValidFamily checks if a family prefix can be used in IDs (letters only)
+func IsValidFamily(family string) bool {
+	return family != "" && isAlpha(family)
+}
+
+// isAlpha checks if string contains only alphabetic characters
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if !((r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z')) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseID(t *testing.T) {
+	tests := []struct {
+		name    string
+		grammar IDGrammar
+		input   string
+		family  string
+		path    []int
+		str     string // String() in the grammar
+		wantErr bool
+	}{
+		{name: "milestone", input: "D3", family: "D", path: []int{3}, str: "D3"},
+		{name: "nested", input: "D3.1.2", family: "D", path: []int{3, 1, 2}, str: "D3.1.2"},
+		{name: "multi-letter family", input: "API12.3", family: "API", path: []int{12, 3}, str: "API12.3"},
+		{name: "separator", grammar: IDGrammar{Separator: "-"}, input: "OPS-12.3", family: "OPS", path: []int{12, 3}, str: "OPS-12.3"},
+		{name: "separator optional when parsing", grammar: IDGrammar{Separator: "-"}, input: "OPS12.3", family: "OPS", path: []int{12, 3}, str: "OPS-12.3"},
+		{name: "padding", grammar: IDGrammar{Padding: 3}, input: "D3.1", family: "D", path: []int{3, 1}, str: "D003.1"},
+		{name: "padding optional when parsing", grammar: IDGrammar{Padding: 3}, input: "D003.1", family: "D", path: []int{3, 1}, str: "D003.1"},
+		{name: "empty", input: "", wantErr: true},
+		{name: "no family", input: "3.1", wantErr: true},
+		{name: "no number", input: "D", wantErr: true},
+		{name: "bad level", input: "D3.x", wantErr: true},
+		{name: "negative", input: "D3.-1", wantErr: true},
+		{name: "signed", input: "D+3", wantErr: true},
+		{name: "trailing dot", input: "D3.", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Grammar = tt.grammar
+			defer func() { Grammar = IDGrammar{} }()
+
+			id, err := ParseID(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseID(%q) = %v, want an error", tt.input, id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseID(%q): %v", tt.input, err)
+			}
+			if id.Family != tt.family || !reflect.DeepEqual(id.Path, tt.path) {
+				t.Errorf("ParseID(%q) = %s %v, want %s %v", tt.input, id.Family, id.Path, tt.family, tt.path)
+			}
+			if got := id.String(); got != tt.str {
+				t.Errorf("String() = %s, want %s", got, tt.str)
+			}
+		})
+	}
+}
+
+func TestSameID(t *testing.T) {
+	Grammar = IDGrammar{Separator: "-", Padding: 3}
+	defer func() { Grammar = IDGrammar{} }()
+
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"D3.1", "D-003.1", true},
+		{"D3.1", "D3.01", true},
+		{"D3.1", "D3.1.0", false},
+		{"D3.1", "E3.1", false},
+		{"D3", "D30", false},
+		{"not an id", "not an id", true},
+		{"not an id", "D3", false},
+	}
+
+	for _, tt := range tests {
+		if got := SameID(tt.a, tt.b); got != tt.want {
+			t.Errorf("SameID(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCompareIDs(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int // sign only
+	}{
+		{"D3.2", "D3.10", -1},
+		{"D3", "D3.1", -1},
+		{"E1", "D9", 1},
+		{"D3.1", "D3.1", 0},
+	}
+
+	for _, tt := range tests {
+		got := CompareIDs(tt.a, tt.b)
+		if (got < 0) != (tt.want < 0) || (got > 0) != (tt.want > 0) {
+			t.Errorf("CompareIDs(%q, %q) = %d, want sign of %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
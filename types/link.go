@@ -0,0 +1,94 @@
+package types
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// Link kinds
+const (
+	LinkIssue    = "issue"
+	LinkPR       = "pr"
+	LinkDoc      = "doc"
+	LinkDesign   = "design"
+	LinkIncident = "incident"
+	LinkOther    = "link"
+)
+
+// LinkKinds lists the kinds a link may have
+var LinkKinds = []string{LinkIssue, LinkPR, LinkDoc, LinkDesign, LinkIncident, LinkOther}
+
+// Link points an item at something outside the roadmap: its issue, pull
+// request, design doc or incident
+type Link struct {
+	Kind  string `yaml:"kind" json:"kind"`
+	URL   string `yaml:"url" json:"url"`
+	Title string `yaml:"title,omitempty" json:"title,omitempty"`
+}
+
+// linkPatterns recognise well-known URL shapes, checked in order
+var linkPatterns = []struct {
+	kind    string
+	pattern *regexp.Regexp
+}{
+	{LinkPR, regexp.MustCompile(`/pull/\d+|/merge_requests/\d+|/pull-requests/\d+`)},
+	{LinkIncident, regexp.MustCompile(`pagerduty\.com/incidents|incident\.io|opsgenie\.com|/incidents?/|/postmortems?/`)},
+	{LinkIssue, regexp.MustCompile(`/issues/\d+|/-/issues/|/browse/[A-Z][A-Z0-9]+-\d+|linear\.app/.+/issue/|/tickets?/`)},
+	{LinkDesign, regexp.MustCompile(`figma\.com|miro\.com|excalidraw\.com|sketch\.cloud|invisionapp\.com|whimsical\.com`)},
+	{LinkDoc, regexp.MustCompile(`docs\.google\.com|notion\.so|atlassian\.net/wiki|/wiki/|/docs?/|\.md$|\.pdf$|/blob/.+\.(md|rst|txt)$`)},
+}
+
+// DetectLinkKind guesses a link's kind from its URL
+func DetectLinkKind(rawURL string) string {
+	for _, candidate := range linkPatterns {
+		if candidate.pattern.MatchString(rawURL) {
+			return candidate.kind
+		}
+	}
+	return LinkOther
+}
+
+// IsValidLinkKind reports whether kind is one of LinkKinds
+func IsValidLinkKind(kind string) bool {
+	for _, valid := range LinkKinds {
+		if kind == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateLinkURL checks that a link is an absolute URL
+func ValidateLinkURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("invalid link URL: %q (expected an absolute URL such as https://...)", rawURL)
+	}
+	return nil
+}
+
+// shortRefPattern pulls owner/repo and a number out of forge URLs
+var shortRefPattern = regexp.MustCompile(`^https?://[^/]+/([^/]+/[^/]+?)(?:/-)?/(?:pull|issues|merge_requests|pull-requests)/(\d+)`)
+
+// Label returns the link's title, "owner/repo#12" for forge issues and pull
+// requests, or else the URL itself
+func (l Link) Label() string {
+	if l.Title != "" {
+		return l.Title
+	}
+	if match := shortRefPattern.FindStringSubmatch(l.URL); match != nil {
+		return match[1] + "#" + match[2]
+	}
+	return l.URL
+}
+
+// HasLink reports whether the item already links to rawURL
+func (d *Details) HasLink(rawURL string) bool {
+	for _, link := range d.Links {
+		if link.URL == rawURL {
+			return true
+		}
+	}
+	return false
+}
@@ -1,168 +1,251 @@
 package types
 
 import (
-	"fmt"
-	"strconv"
 	"strings"
+	"time"
+	"unicode"
 )
 
-// Enhanced ID structure for family-prefixed hierarchical IDs
-type ID struct {
-	Family    string // D, E, F (product families)
-	Milestone int    // 3, 4, 5 (major features)
-	Task      *int   // 1, 2, 3 (nil for milestone-only IDs)
-	Subtask   *int   // 1, 2, 3 (nil for task-only IDs)
-}
+// CurrentSchemaVersion is the roadmap file format written by this build.
+// Files without a schema_version key predate versioning and load as version 1.
+const CurrentSchemaVersion = 2
 
-// String returns the ID in family-prefixed format (D3, D3.1, D3.1.1)
-func (id ID) String() string {
-	if id.Subtask != nil {
-		return fmt.Sprintf("%s%d.%d.%d", id.Family, id.Milestone, *id.Task, *id.Subtask)
-	}
-	if id.Task != nil {
-		return fmt.Sprintf("%s%d.%d", id.Family, id.Milestone, *id.Task)
-	}
-	return fmt.Sprintf("%s%d", id.Family, id.Milestone)
-}
+// Details holds the planning fields shared by milestones and tasks
+type Details struct {
+	DependsOn []string  `yaml:"depends_on,omitempty" json:"depends_on,omitempty"`
+	Size      string    `yaml:"size,omitempty" json:"size,omitempty"`
+	Points    float64   `yaml:"points,omitempty" json:"points,omitempty"`
+	Assignees []string  `yaml:"assignees,omitempty" json:"assignees,omitempty"`
+	Start     Date      `yaml:"start,omitempty" json:"start,omitempty"`
+	Due       Date      `yaml:"due,omitempty" json:"due,omitempty"`
+	Target    Date      `yaml:"target,omitempty" json:"target,omitempty"`
+	Labels    []string  `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Links     []Link    `yaml:"links,omitempty" json:"links,omitempty"`
+	Comments  []Comment `yaml:"comments,omitempty" json:"comments,omitempty"`
 
-// CommitTag returns the ID formatted for git commit tags
-func (id ID) CommitTag() string {
-	return fmt.Sprintf("[%s]", id.String())
-}
+	// StatusLocked keeps the status as set by hand instead of rolling it up
+	// from children
+	StatusLocked bool `yaml:"status_locked,omitempty" json:"status_locked,omitempty"`
 
-// ParentID returns the parent ID (D3.1.1 → D3.1, D3.1 → D3, D3 → "")
-func (id ID) ParentID() string {
-	if id.Subtask != nil {
-		return fmt.Sprintf("%s%d.%d", id.Family, id.Milestone, *id.Task)
-	}
-	if id.Task != nil {
-		return fmt.Sprintf("%s%d", id.Family, id.Milestone)
-	}
-	return "" // Milestone has no parent
+	// Maintained by core when items are added, updated or change status
+	CreatedAt   *time.Time `yaml:"created_at,omitempty" json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `yaml:"updated_at,omitempty" json:"updated_at,omitempty"`
+	StartedAt   *time.Time `yaml:"started_at,omitempty" json:"started_at,omitempty"`
+	CompletedAt *time.Time `yaml:"completed_at,omitempty" json:"completed_at,omitempty"`
 }
 
-// Level returns the hierarchy level (0=milestone, 1=task, 2=subtask)
-func (id ID) Level() int {
-	if id.Subtask != nil {
-		return 2
-	}
-	if id.Task != nil {
-		return 1
+// End returns the date the item must be finished by: its due date, or its
+// target date when no hard deadline is set
+func (d *Details) End() Date {
+	if d.Due.IsSet() {
+		return d.Due
 	}
-	return 0
+	return d.Target
 }
 
-// ParseID parses a string into an ID structure
-func ParseID(idStr string) (*ID, error) {
-	if idStr == "" {
-		return nil, fmt.Errorf("empty ID")
-	}
-
-	// Extract family (first character must be alphabetic)
-	if len(idStr) < 2 {
-		return nil, fmt.Errorf("invalid ID format: %s", idStr)
-	}
-
-	family := string(idStr[0])
-	if !isAlpha(family) {
-		return nil, fmt.Errorf("family must be alphabetic: %s", family)
-	}
-
-	// Parse numeric parts
-	parts := strings.Split(idStr[1:], ".")
-	if len(parts) == 0 || len(parts) > 3 {
-		return nil, fmt.Errorf("invalid ID format: %s", idStr)
-	}
-
-	id := &ID{Family: family}
-
-	// Parse milestone
-	milestone, err := strconv.Atoi(parts[0])
-	if err != nil {
-		return nil, fmt.Errorf("invalid milestone number: %s", parts[0])
-	}
-	id.Milestone = milestone
-
-	// Parse task if present
-	if len(parts) > 1 {
-		task, err := strconv.Atoi(parts[1])
-		if err != nil {
-			return nil, fmt.Errorf("invalid task number: %s", parts[1])
+// IsAssignedTo reports whether any of the given identities is an assignee
+func (d *Details) IsAssignedTo(identities ...string) bool {
+	for _, assignee := range d.Assignees {
+		for _, identity := range identities {
+			if identity != "" && strings.EqualFold(assignee, identity) {
+				return true
+			}
 		}
-		id.Task = &task
 	}
+	return false
+}
 
-	// Parse subtask if present
-	if len(parts) > 2 {
-		subtask, err := strconv.Atoi(parts[2])
-		if err != nil {
-			return nil, fmt.Errorf("invalid subtask number: %s", parts[2])
+// HasLabel reports whether the item carries label, ignoring case
+func (d *Details) HasLabel(label string) bool {
+	for _, existing := range d.Labels {
+		if strings.EqualFold(existing, label) {
+			return true
 		}
-		id.Subtask = &subtask
 	}
-
-	return id, nil
+	return false
 }
 
-// isAlpha checks if string contains only alphabetic characters
-func isAlpha(s string) bool {
-	for _, r := range s {
-		if !((r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z')) {
-			return false
-		}
+// Estimate returns the item's own estimate in points: explicit points win,
+// otherwise the size is converted through the size scale
+func (d *Details) Estimate() float64 {
+	if d.Points > 0 {
+		return d.Points
 	}
-	return true
+	return SizePoints[d.Size]
 }
 
 // Milestone represents a high-level feature or goal
 type Milestone struct {
-	ID           string            `yaml:"id"`
-	Family       string            `yaml:"family"`
-	Title        string            `yaml:"title"`
-	Priority     string            `yaml:"priority,omitempty"`
-	CycleStatus  string            `yaml:"cycle_status,omitempty"`
-	Status       string            `yaml:"status,omitempty"`
-	Notes        string            `yaml:"notes,omitempty"`
-	Metadata     map[string]string `yaml:"metadata,omitempty"`
+	ID            string            `yaml:"id" json:"id"`
+	Family        string            `yaml:"family" json:"family"`
+	Title         string            `yaml:"title" json:"title"`
+	Priority      string            `yaml:"priority,omitempty" json:"priority,omitempty"`
+	CycleStatus   string            `yaml:"cycle_status,omitempty" json:"cycle_status,omitempty"`
+	Status        string            `yaml:"status,omitempty" json:"status,omitempty"`
+	ReleaseStatus string            `yaml:"release_status,omitempty" json:"release_status,omitempty"`
+	Week          string            `yaml:"week,omitempty" json:"week,omitempty"`
+	Version       string            `yaml:"version,omitempty" json:"version,omitempty"`
+	SuccessGate   string            `yaml:"success_gate,omitempty" json:"success_gate,omitempty"`
+	Gates         []Gate            `yaml:"gates,omitempty" json:"gates,omitempty"`
+	Notes         string            `yaml:"notes,omitempty" json:"notes,omitempty"`
+	Metadata      map[string]string `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+
+	Details `yaml:",inline"`
 }
 
 // Task represents a specific work item under a milestone
 type Task struct {
-	ID       string            `yaml:"id"`
-	ParentID string            `yaml:"parent_id"`
-	Title    string            `yaml:"title"`
-	Status   string            `yaml:"status"`
-	Priority string            `yaml:"priority,omitempty"`
-	Notes    string            `yaml:"notes,omitempty"`
-	Metadata map[string]string `yaml:"metadata,omitempty"`
+	ID       string            `yaml:"id" json:"id"`
+	ParentID string            `yaml:"parent_id" json:"parent_id"`
+	Title    string            `yaml:"title" json:"title"`
+	Status   string            `yaml:"status" json:"status"`
+	Priority string            `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Notes    string            `yaml:"notes,omitempty" json:"notes,omitempty"`
+	Metadata map[string]string `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+
+	Details `yaml:",inline"`
+}
+
+// Family describes a product family that milestones belong to
+type Family struct {
+	Code            string `yaml:"code" json:"code"`
+	Name            string `yaml:"name" json:"name"`
+	Description     string `yaml:"description,omitempty" json:"description,omitempty"`
+	Owner           string `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Color           string `yaml:"color,omitempty" json:"color,omitempty"`
+	DefaultPriority string `yaml:"default_priority,omitempty" json:"default_priority,omitempty"`
 }
 
 // Roadmap represents the entire roadmap structure
 type Roadmap struct {
-	Milestones []Milestone `yaml:"milestones"`
-	Tasks      []Task      `yaml:"tasks"`
+	SchemaVersion int         `yaml:"schema_version,omitempty" json:"schema_version,omitempty"`
+	Families      []Family    `yaml:"families,omitempty" json:"families,omitempty"`
+	Milestones    []Milestone `yaml:"milestones" json:"milestones"`
+	Tasks         []Task      `yaml:"tasks" json:"tasks"`
+	Cycles        []Cycle     `yaml:"cycles,omitempty" json:"cycles,omitempty"`
+	Releases      []Release   `yaml:"releases,omitempty" json:"releases,omitempty"`
+
+	// Changes made through core since the roadmap was loaded, written to the
+	// history log on save
+	Changes []Change `yaml:"-" json:"-"`
+
+	// Source is the file the roadmap was loaded from. Saving patches it
+	// rather than rewriting it, so hand edits and comments survive.
+	Source []byte `yaml:"-" json:"-"`
+
+	// Migrated notes each conversion made loading an older file format
+	Migrated []string `yaml:"-" json:"-"`
+
+	// Files maps each milestone and task of a roadmap split across a
+	// directory to the file it came from, and Sources holds each of those
+	// files as loaded, both relative to the directory
+	Files   map[string]string `yaml:"-" json:"-"`
+	Sources map[string][]byte `yaml:"-" json:"-"`
 }
 
 // Context represents the current working context
 type Context struct {
-	MilestoneID string `json:"milestone_id,omitempty"`
-	TaskID      string `json:"task_id,omitempty"`
-	Family      string `json:"family,omitempty"`
+	ProjectName   string    `json:"project_name,omitempty"`
+	MilestoneID   string    `json:"milestone_id,omitempty"`
+	TaskID        string    `json:"task_id,omitempty"`
+	Family        string    `json:"family,omitempty"`
+	LastUpdated   time.Time `json:"last_updated"`
+	CommitHistory []string  `json:"commit_history,omitempty"`
+}
+
+// Person is a member of the project roster
+type Person struct {
+	Handle string `json:"handle"`
+	Name   string `json:"name,omitempty"`
+	Email  string `json:"email,omitempty"`
+}
+
+// Identities returns every string the person may be assigned under
+func (p Person) Identities() []string {
+	return []string{p.Handle, p.Name, p.Email}
+}
+
+// ProjectConfig represents a configured project
+type ProjectConfig struct {
+	Name       string    `json:"name"`
+	YAMLPath   string    `json:"yaml_path"`
+	SchemaPath string    `json:"schema_path,omitempty"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 // Config represents the user configuration
 type Config struct {
-	Context Context `json:"context"`
+	Context       Context                  `json:"context"`
+	ActiveProject string                   `json:"active_project,omitempty"`
+	Projects      map[string]ProjectConfig `json:"projects,omitempty"`
+	LastUpdated   time.Time                `json:"last_updated"`
+
+	// MaxDepth limits how far below a milestone items may nest
+	MaxDepth int `json:"max_depth,omitempty"`
+
+	// IDs configures how IDs are written (separator, zero-padding)
+	IDs IDGrammar `json:"ids,omitempty"`
+
+	// SizePoints maps t-shirt sizes to points for effort rollups
+	SizePoints map[string]float64 `json:"size_points,omitempty"`
+
+	// People is the team roster items can be assigned to
+	People []Person `json:"people,omitempty"`
+
+	// CustomFields types keys in item metadata
+	CustomFields []CustomField `json:"custom_fields,omitempty"`
+
+	// StatusRollup overrides the rules that derive parent statuses
+	StatusRollup []RollupRule `json:"status_rollup,omitempty"`
+
+	// Workflows replace the default state machines for status fields
+	Workflows map[string]*Workflow `json:"workflows,omitempty"`
+
+	// Storage selects the backend the roadmap is kept in
+	Storage Storage `json:"storage,omitempty"`
+}
+
+// FindPerson looks up a roster entry by handle, name or email
+func (c *Config) FindPerson(identity string) *Person {
+	for i := range c.People {
+		for _, candidate := range c.People[i].Identities() {
+			if candidate != "" && strings.EqualFold(candidate, identity) {
+				return &c.People[i]
+			}
+		}
+	}
+	return nil
+}
+
+// DefaultFamilies is the registry used by roadmaps that don't declare one
+var DefaultFamilies = []Family{
+	{Code: "D", Name: "Development", Description: "Development/Backend", Color: "cyan"},
+	{Code: "E", Name: "Engineering", Description: "Engineering/Frontend", Color: "blue"},
+	{Code: "F", Name: "Features", Description: "Features/Product", Color: "green"},
+	{Code: "O", Name: "Operations", Description: "Operations/DevOps", Color: "yellow"},
+	{Code: "S", Name: "Security", Description: "Security/Infrastructure", Color: "red"},
 }
 
 // Valid values for enum fields
 var (
 	ValidPriorities = []string{"low", "medium", "high", "critical"}
 	ValidSizes      = []string{"xs", "s", "m", "l", "xl"}
-	ValidCycleStatuses = []string{"planned", "in-cycle", "done"}
-	ValidTaskStatuses  = []string{"planned", "in-progress", "done", "blocked"}
+	ValidColors     = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
 )
 
+// DefaultSizePoints is the size scale used unless the project config sets one
+var DefaultSizePoints = map[string]float64{"xs": 1, "s": 2, "m": 3, "l": 5, "xl": 8}
+
+// SizePoints converts sizes to points; keys are the sizes items may use
+var SizePoints = DefaultSizePoints
+
+// IsFinishedStatus reports whether a status is terminal in the status
+// workflow, meaning no work remains
+func IsFinishedStatus(status string) bool {
+	return WorkflowFor(WorkflowStatus).IsTerminal(status)
+}
+
 // IsValidPriority checks if a priority value is valid
 func IsValidPriority(priority string) bool {
 	for _, valid := range ValidPriorities {
@@ -173,34 +256,82 @@ func IsValidPriority(priority string) bool {
 	return false
 }
 
-// IsValidSize checks if a size value is valid
+// IsValidSize checks if a size value is on the configured size scale
 func IsValidSize(size string) bool {
-	for _, valid := range ValidSizes {
-		if size == valid {
-			return true
+	_, ok := SizePoints[size]
+	return ok
+}
+
+// IsValidLabel checks that a label is a single word of letters, digits and
+// - _ . : / so it can be used in label expressions
+func IsValidLabel(label string) bool {
+	if label == "" {
+		return false
+	}
+	for _, r := range label {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+		case strings.ContainsRune("-_.:/", r):
+		default:
+			return false
 		}
 	}
-	return false
+	return true
 }
 
-// IsValidCycleStatus checks if a cycle status is valid
+// IsValidCycleStatus checks if a cycle status is in the cycle workflow
 func IsValidCycleStatus(status string) bool {
-	for _, valid := range ValidCycleStatuses {
-		if status == valid {
+	return WorkflowFor(WorkflowCycleStatus).State(status) != nil
+}
+
+// IsValidReleaseStatus checks if a release status is in the release
+// status workflow
+func IsValidReleaseStatus(status string) bool {
+	return WorkflowFor(WorkflowReleaseStatus).State(status) != nil
+}
+
+// IsValidTaskStatus checks if a status is in the status workflow
+func IsValidTaskStatus(status string) bool {
+	return WorkflowFor(WorkflowStatus).State(status) != nil
+}
+
+// IsValidColor checks if a color name is one spiral can render
+func IsValidColor(name string) bool {
+	for _, valid := range ValidColors {
+		if name == valid {
 			return true
 		}
 	}
 	return false
 }
 
-// IsValidTaskStatus checks if a task status is valid
-func IsValidTaskStatus(status string) bool {
-	for _, valid := range ValidTaskStatuses {
-		if status == valid {
-			return true
+// FamilyRegistry returns the families declared in the roadmap, or the
+// default registry when the roadmap doesn't declare any
+func (r *Roadmap) FamilyRegistry() []Family {
+	if len(r.Families) > 0 {
+		return r.Families
+	}
+	return DefaultFamilies
+}
+
+// GetFamily finds a registered family by code
+func (r *Roadmap) GetFamily(code string) *Family {
+	if len(r.Families) == 0 {
+		for i := range DefaultFamilies {
+			if DefaultFamilies[i].Code == code {
+				family := DefaultFamilies[i]
+				return &family
+			}
 		}
+		return nil
 	}
-	return false
+
+	for i := range r.Families {
+		if r.Families[i].Code == code {
+			return &r.Families[i]
+		}
+	}
+	return nil
 }
 
 // GetMilestoneByID finds a milestone by ID
@@ -213,6 +344,64 @@ func (r *Roadmap) GetMilestoneByID(id string) *Milestone {
 	return nil
 }
 
+// GetTaskByID finds a task by ID
+func (r *Roadmap) GetTaskByID(id string) *Task {
+	for i := range r.Tasks {
+		if r.Tasks[i].ID == id {
+			return &r.Tasks[i]
+		}
+	}
+	return nil
+}
+
+// MilestoneOf returns the milestone an item sits under, or the item itself
+// when it is a milestone
+func (r *Roadmap) MilestoneOf(id string) *Milestone {
+	parsed, err := ParseID(id)
+	if err != nil {
+		return nil
+	}
+	return r.GetMilestoneByID(ID{Family: parsed.Family, Path: parsed.Path[:1]}.String())
+}
+
+// HasItem reports whether a milestone or task with the given ID exists
+func (r *Roadmap) HasItem(id string) bool {
+	return r.GetMilestoneByID(id) != nil || r.GetTaskByID(id) != nil
+}
+
+// DetailsOf returns the shared planning fields of a milestone or task
+func (r *Roadmap) DetailsOf(id string) *Details {
+	if milestone := r.GetMilestoneByID(id); milestone != nil {
+		return &milestone.Details
+	}
+	if task := r.GetTaskByID(id); task != nil {
+		return &task.Details
+	}
+	return nil
+}
+
+// StatusOf returns the status of a milestone or task
+func (r *Roadmap) StatusOf(id string) (string, bool) {
+	if milestone := r.GetMilestoneByID(id); milestone != nil {
+		return milestone.Status, true
+	}
+	if task := r.GetTaskByID(id); task != nil {
+		return task.Status, true
+	}
+	return "", false
+}
+
+// TitleOf returns the title of a milestone or task
+func (r *Roadmap) TitleOf(id string) string {
+	if milestone := r.GetMilestoneByID(id); milestone != nil {
+		return milestone.Title
+	}
+	if task := r.GetTaskByID(id); task != nil {
+		return task.Title
+	}
+	return ""
+}
+
 // GetTasksByParentID finds all tasks with the given parent ID
 func (r *Roadmap) GetTasksByParentID(parentID string) []Task {
 	var tasks []Task
@@ -224,24 +413,46 @@ func (r *Roadmap) GetTasksByParentID(parentID string) []Task {
 	return tasks
 }
 
+// Descendants returns the IDs of every task below id, at any depth
+func (r *Roadmap) Descendants(id string) []string {
+	var ids []string
+	seen := map[string]bool{id: true}
+	for queue := []string{id}; len(queue) > 0; queue = queue[1:] {
+		for _, task := range r.Tasks {
+			if task.ParentID == queue[0] && !seen[task.ID] {
+				seen[task.ID] = true
+				ids = append(ids, task.ID)
+				queue = append(queue, task.ID)
+			}
+		}
+	}
+	return ids
+}
+
 // GetInCycleTasks returns all tasks that are in the current cycle
 func (r *Roadmap) GetInCycleTasks() []Task {
 	var tasks []Task
-	
-	// First find milestones that are in-cycle
-	inCycleMilestones := make(map[string]bool)
+
+	// First find milestones that are in-cycle, plus anything planned into
+	// the active cycle
+	inCycle := make(map[string]bool)
 	for _, milestone := range r.Milestones {
-		if milestone.CycleStatus == "in-cycle" {
-			inCycleMilestones[milestone.ID] = true
+		if WorkflowFor(WorkflowCycleStatus).IsWorking(milestone.CycleStatus) {
+			inCycle[milestone.ID] = true
+		}
+	}
+	if cycle := r.ActiveCycle(); cycle != nil {
+		for _, id := range cycle.Items {
+			inCycle[id] = true
 		}
 	}
-	
-	// Then find tasks under those milestones
+
+	// Then find tasks under those milestones, or planned in themselves
 	for _, task := range r.Tasks {
-		if inCycleMilestones[task.ParentID] {
+		if inCycle[task.ParentID] || inCycle[task.ID] {
 			tasks = append(tasks, task)
 		}
 	}
-	
+
 	return tasks
-} 
\ No newline at end of file
+}
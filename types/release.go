@@ -0,0 +1,40 @@
+package types
+
+// Release is a version that milestones ship in. Milestones join a release
+// through their version field.
+type Release struct {
+	Name   string `yaml:"name" json:"name"`
+	Target Date   `yaml:"target,omitempty" json:"target,omitempty"`
+	Status string `yaml:"status,omitempty" json:"status,omitempty"`
+	Notes  string `yaml:"notes,omitempty" json:"notes,omitempty"`
+}
+
+// GetRelease finds a release by name
+func (r *Roadmap) GetRelease(name string) *Release {
+	for i := range r.Releases {
+		if r.Releases[i].Name == name {
+			return &r.Releases[i]
+		}
+	}
+	return nil
+}
+
+// ReleaseMilestones returns the milestones assigned to a release
+func (r *Roadmap) ReleaseMilestones(name string) []*Milestone {
+	var milestones []*Milestone
+	for i := range r.Milestones {
+		if r.Milestones[i].Version == name {
+			milestones = append(milestones, &r.Milestones[i])
+		}
+	}
+	return milestones
+}
+
+// ReleaseOf returns the release an item ships in: its own version for a
+// milestone, or its milestone's for a task
+func (r *Roadmap) ReleaseOf(id string) string {
+	if milestone := r.MilestoneOf(id); milestone != nil {
+		return milestone.Version
+	}
+	return ""
+}
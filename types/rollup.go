@@ -0,0 +1,114 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rollup rule quantifiers
+const (
+	RollupAll = "all"
+	RollupAny = "any"
+)
+
+// RollupRule derives a parent's status from its children: when all (or any)
+// of the children have one of Statuses, the parent becomes Then.
+type RollupRule struct {
+	When     string   `json:"when"`
+	Statuses []string `json:"statuses"`
+	Then     string   `json:"then"`
+}
+
+// Matches reports whether the rule applies to a set of child statuses
+func (r RollupRule) Matches(children []string) bool {
+	if len(children) == 0 {
+		return false
+	}
+
+	listed := func(status string) bool {
+		for _, s := range r.Statuses {
+			if s == status {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, child := range children {
+		if r.When == RollupAny && listed(child) {
+			return true
+		}
+		if r.When == RollupAll && !listed(child) {
+			return false
+		}
+	}
+	return r.When == RollupAll
+}
+
+// DefaultRollupRules derives the rules used when the project config sets
+// none from a workflow's roles: a parent is dropped when every child was,
+// completed when every child is terminal, and working once any child is
+// working or completed. With the default workflow that is cancelled, done
+// and in-progress. The first matching rule wins.
+func DefaultRollupRules(workflow *Workflow) []RollupRule {
+	var terminal, dropped, started []string
+	for _, state := range workflow.States {
+		switch {
+		case workflow.IsDropped(state.Name):
+			dropped = append(dropped, state.Name)
+			terminal = append(terminal, state.Name)
+		case state.Terminal:
+			terminal = append(terminal, state.Name)
+			started = append(started, state.Name)
+		case workflow.IsWorking(state.Name):
+			started = append(started, state.Name)
+		}
+	}
+
+	var rules []RollupRule
+	if len(dropped) > 0 {
+		rules = append(rules, RollupRule{When: RollupAll, Statuses: dropped, Then: workflow.Dropped()})
+	}
+	if completed := workflow.Completed(); completed != "" {
+		rules = append(rules, RollupRule{When: RollupAll, Statuses: terminal, Then: completed})
+	}
+	if working := workflow.Working(); workflow.IsWorking(working) {
+		rules = append(rules, RollupRule{When: RollupAny, Statuses: started, Then: working})
+	}
+	return rules
+}
+
+// RollupRules are the status rollup rules from the project config, or nil
+// to derive them from the status workflow
+var RollupRules []RollupRule
+
+// ActiveRollupRules returns the configured rollup rules, or the defaults
+// for the active status workflow
+func ActiveRollupRules() []RollupRule {
+	if RollupRules != nil {
+		return RollupRules
+	}
+	return DefaultRollupRules(WorkflowFor(WorkflowStatus))
+}
+
+// ValidateRollupRules checks a set of rollup rules against the status
+// workflow they apply to
+func ValidateRollupRules(rules []RollupRule, workflow *Workflow) error {
+	for i, rule := range rules {
+		if rule.When != RollupAll && rule.When != RollupAny {
+			return fmt.Errorf("rule %d: when must be %q or %q, got %q", i+1, RollupAll, RollupAny, rule.When)
+		}
+		if len(rule.Statuses) == 0 {
+			return fmt.Errorf("rule %d: statuses cannot be empty", i+1)
+		}
+		if rule.Then == "" {
+			return fmt.Errorf("rule %d: then cannot be empty", i+1)
+		}
+		for _, status := range append(rule.Statuses, rule.Then) {
+			if workflow.State(status) == nil {
+				return fmt.Errorf("rule %d: unknown status %q (allowed: %s)", i+1, status, strings.Join(workflow.Names(), ", "))
+			}
+		}
+	}
+	return nil
+}
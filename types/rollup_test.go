@@ -0,0 +1,95 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDefaultRollupRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		workflow *Workflow
+		want     []string // "when [statuses] → then" per rule
+	}{
+		{
+			name:     "default workflow",
+			workflow: DefaultWorkflows[WorkflowStatus],
+			want: []string{
+				"all [cancelled] → cancelled",
+				"all [done cancelled] → done",
+				"any [in-progress done] → in-progress",
+			},
+		},
+		{
+			name: "custom names",
+			workflow: &Workflow{States: []WorkflowState{
+				{Name: "todo"}, {Name: "doing"}, {Name: "stuck", Blocked: true},
+				{Name: "shipped", Terminal: true}, {Name: "dropped", Terminal: true, Dropped: true},
+			}},
+			want: []string{
+				"all [dropped] → dropped",
+				"all [shipped dropped] → shipped",
+				"any [doing shipped] → doing",
+			},
+		},
+		{
+			name: "no working state",
+			workflow: &Workflow{States: []WorkflowState{
+				{Name: "open"}, {Name: "closed", Terminal: true},
+			}},
+			want: []string{"all [closed] → closed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, rule := range DefaultRollupRules(tt.workflow) {
+				got = append(got, fmt.Sprintf("%s %v → %s", rule.When, rule.Statuses, rule.Then))
+				if err := ValidateRollupRules([]RollupRule{rule}, tt.workflow); err != nil {
+					t.Errorf("derived rule is invalid: %v", err)
+				}
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("rules:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestValidateRollupRules(t *testing.T) {
+	workflow := &Workflow{States: []WorkflowState{{Name: "todo"}, {Name: "done", Terminal: true}}}
+	tests := []struct {
+		name    string
+		rule    RollupRule
+		wantErr string
+	}{
+		{name: "valid", rule: RollupRule{When: RollupAll, Statuses: []string{"done"}, Then: "done"}},
+		{name: "bad quantifier", rule: RollupRule{When: "most", Statuses: []string{"done"}, Then: "done"}, wantErr: "when must be"},
+		{name: "no statuses", rule: RollupRule{When: RollupAll, Then: "done"}, wantErr: "statuses cannot be empty"},
+		{name: "no result", rule: RollupRule{When: RollupAll, Statuses: []string{"done"}}, wantErr: "then cannot be empty"},
+		{
+			name:    "unknown child status",
+			rule:    RollupRule{When: RollupAny, Statuses: []string{"in-progress"}, Then: "todo"},
+			wantErr: `unknown status "in-progress"`,
+		},
+		{
+			name:    "unknown result",
+			rule:    RollupRule{When: RollupAny, Statuses: []string{"done"}, Then: "in-progress"},
+			wantErr: `unknown status "in-progress"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRollupRules([]RollupRule{tt.rule}, workflow)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("ValidateRollupRules: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("ValidateRollupRules error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package types
+
+import "strings"
+
+// Schema sections, matching the top-level keys of a roadmap file
+const (
+	SchemaFamilies   = "families"
+	SchemaMilestones = "milestones"
+	SchemaTasks      = "tasks"
+	SchemaCycles     = "cycles"
+	SchemaReleases   = "releases"
+)
+
+// Field types understood by the validation engine
+const (
+	FieldString = "string"
+	FieldInt    = "int"
+	FieldNumber = "number"
+	FieldBool   = "bool"
+	FieldDate   = "date"
+	FieldTime   = "timestamp"
+	FieldList   = "list"
+	FieldMap    = "map"
+)
+
+// FieldRule describes the constraints on a single roadmap field
+type FieldRule struct {
+	Required bool     `yaml:"required"`
+	Type     string   `yaml:"type,omitempty"`
+	Enum     []string `yaml:"enum,omitempty"`
+}
+
+// Allows reports whether value satisfies the rule's enum (if any)
+func (r FieldRule) Allows(value string) bool {
+	if len(r.Enum) == 0 {
+		return true
+	}
+	for _, valid := range r.Enum {
+		if value == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedValues returns the enum as a human readable list
+func (r FieldRule) AllowedValues() string {
+	return strings.Join(r.Enum, ", ")
+}
+
+// Schema holds the field rules for every item kind in a roadmap
+type Schema struct {
+	Families   map[string]FieldRule `yaml:"families"`
+	Milestones map[string]FieldRule `yaml:"milestones"`
+	Tasks      map[string]FieldRule `yaml:"tasks"`
+	Cycles     map[string]FieldRule `yaml:"cycles"`
+	Releases   map[string]FieldRule `yaml:"releases"`
+}
+
+// Section returns the field rules for a schema section (milestones or tasks)
+func (s *Schema) Section(section string) map[string]FieldRule {
+	switch section {
+	case SchemaFamilies:
+		return s.Families
+	case SchemaMilestones:
+		return s.Milestones
+	case SchemaTasks:
+		return s.Tasks
+	case SchemaCycles:
+		return s.Cycles
+	case SchemaReleases:
+		return s.Releases
+	default:
+		return nil
+	}
+}
+
+// Rule returns the rule for a field in a schema section
+func (s *Schema) Rule(section, field string) (FieldRule, bool) {
+	rule, ok := s.Section(section)[field]
+	return rule, ok
+}
+
+// Merge overlays another schema on top of this one. Fields present in the
+// override replace the corresponding rule entirely.
+func (s *Schema) Merge(override *Schema) {
+	if override == nil {
+		return
+	}
+	s.Families = mergeRules(s.Families, override.Families)
+	s.Milestones = mergeRules(s.Milestones, override.Milestones)
+	s.Tasks = mergeRules(s.Tasks, override.Tasks)
+	s.Cycles = mergeRules(s.Cycles, override.Cycles)
+	s.Releases = mergeRules(s.Releases, override.Releases)
+}
+
+// ApplyWorkflows makes each workflow's states the allowed values of the
+// field it governs, so the workflow is the one place statuses are defined.
+// Releases share the release_status workflow for their status.
+func (s *Schema) ApplyWorkflows(workflows map[string]*Workflow) {
+	for field, workflow := range workflows {
+		for _, rules := range []map[string]FieldRule{s.Milestones, s.Tasks} {
+			if rule, ok := rules[field]; ok {
+				rule.Enum = workflow.Names()
+				rules[field] = rule
+			}
+		}
+		if rule, ok := s.Releases["status"]; ok && field == WorkflowReleaseStatus {
+			rule.Enum = workflow.Names()
+			s.Releases["status"] = rule
+		}
+	}
+}
+
+// mergeRules copies override rules over base, allocating base if needed
+func mergeRules(base, override map[string]FieldRule) map[string]FieldRule {
+	if base == nil {
+		base = make(map[string]FieldRule)
+	}
+	for field, rule := range override {
+		base[field] = rule
+	}
+	return base
+}
@@ -0,0 +1,18 @@
+package types
+
+// Storage backends a roadmap can be kept in
+const (
+	StorageYAML     = "yaml"
+	StorageJSON     = "json"
+	StorageMarkdown = "markdown"
+)
+
+// StorageBackends lists the backends in the order they are documented
+var StorageBackends = []string{StorageYAML, StorageJSON, StorageMarkdown}
+
+// Storage selects where the roadmap is kept. An empty backend means yaml,
+// and an empty path the backend's default location.
+type Storage struct {
+	Backend string `json:"backend,omitempty"`
+	Path    string `json:"path,omitempty"`
+}
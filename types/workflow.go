@@ -0,0 +1,256 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Fields governed by a workflow
+const (
+	WorkflowStatus        = "status"
+	WorkflowCycleStatus   = "cycle_status"
+	WorkflowReleaseStatus = "release_status"
+)
+
+// WorkflowState is one status in a workflow
+type WorkflowState struct {
+	Name  string `json:"name"`
+	Icon  string `json:"icon,omitempty"`
+	Color string `json:"color,omitempty"`
+
+	// Terminal states mean no work remains (done, cancelled)
+	Terminal bool `json:"terminal,omitempty"`
+
+	// Dropped terminal states mean the work was abandoned rather than
+	// finished (cancelled), so it counts towards no effort or progress
+	Dropped bool `json:"dropped,omitempty"`
+
+	// Gated states can only be entered once a milestone's gates pass (done)
+	Gated bool `json:"gated,omitempty"`
+
+	// Blocked states mean the work cannot proceed (blocked)
+	Blocked bool `json:"blocked,omitempty"`
+
+	// Transitions lists the states this one may move to. A state without a
+	// transitions list may move anywhere.
+	Transitions []string `json:"transitions,omitempty"`
+}
+
+// Workflow is the state machine for a status field. The first state is
+// where new items start.
+type Workflow struct {
+	States []WorkflowState `json:"states"`
+}
+
+// DefaultWorkflows apply to any field the project config doesn't define
+var DefaultWorkflows = map[string]*Workflow{
+	WorkflowStatus: {States: []WorkflowState{
+		{Name: "planned", Icon: "📝", Color: "cyan", Transitions: []string{"in-progress", "blocked", "done", "cancelled"}},
+		{Name: "in-progress", Icon: "🔄", Color: "yellow", Transitions: []string{"planned", "blocked", "done", "cancelled"}},
+		{Name: "blocked", Icon: "🚫", Color: "red", Blocked: true, Transitions: []string{"planned", "in-progress", "cancelled"}},
+		{Name: "done", Icon: "✅", Color: "green", Terminal: true, Gated: true, Transitions: []string{"in-progress"}},
+		{Name: "cancelled", Icon: "❌", Color: "white", Terminal: true, Dropped: true, Transitions: []string{"planned"}},
+	}},
+	WorkflowCycleStatus: {States: []WorkflowState{
+		{Name: "planned", Icon: "📋", Color: "cyan", Transitions: []string{"in-cycle", "done"}},
+		{Name: "in-cycle", Icon: "🔄", Color: "magenta", Transitions: []string{"planned", "done"}},
+		{Name: "done", Icon: "✅", Color: "green", Terminal: true, Transitions: []string{"in-cycle"}},
+	}},
+	WorkflowReleaseStatus: {States: []WorkflowState{
+		{Name: "planned", Icon: "📋", Color: "cyan"},
+		{Name: "parked", Icon: "💤", Color: "white"},
+		{Name: "in-progress", Icon: "🔄", Color: "yellow"},
+		{Name: "done", Icon: "🚀", Color: "green", Terminal: true},
+		{Name: "cancelled", Icon: "❌", Color: "white", Terminal: true, Dropped: true},
+	}},
+}
+
+// Workflows are the active workflows by field name
+var Workflows = DefaultWorkflows
+
+// WorkflowFor returns the workflow governing a field, or nil
+func WorkflowFor(field string) *Workflow {
+	return Workflows[field]
+}
+
+// State finds a state by name
+func (w *Workflow) State(name string) *WorkflowState {
+	for i := range w.States {
+		if w.States[i].Name == name {
+			return &w.States[i]
+		}
+	}
+	return nil
+}
+
+// Names lists the state names in order
+func (w *Workflow) Names() []string {
+	names := make([]string, len(w.States))
+	for i, state := range w.States {
+		names[i] = state.Name
+	}
+	return names
+}
+
+// Initial returns the state new items start in
+func (w *Workflow) Initial() string {
+	if len(w.States) == 0 {
+		return ""
+	}
+	return w.States[0].Name
+}
+
+// IsTerminal reports whether a state means no work remains
+func (w *Workflow) IsTerminal(name string) bool {
+	state := w.State(name)
+	return state != nil && state.Terminal
+}
+
+// IsDropped reports whether a state means the work was abandoned
+func (w *Workflow) IsDropped(name string) bool {
+	state := w.State(name)
+	return state != nil && state.Terminal && state.Dropped
+}
+
+// IsCompleted reports whether a state means the work was finished: terminal
+// but not dropped
+func (w *Workflow) IsCompleted(name string) bool {
+	return w.IsTerminal(name) && !w.IsDropped(name)
+}
+
+// IsWorking reports whether a state means work is under way: a known
+// state past the initial one that is neither terminal nor blocked
+func (w *Workflow) IsWorking(name string) bool {
+	state := w.State(name)
+	return state != nil && name != w.Initial() && !state.Terminal && !state.Blocked
+}
+
+// Working returns the first working state, or the initial one when the
+// workflow has none
+func (w *Workflow) Working() string {
+	for _, state := range w.States {
+		if w.IsWorking(state.Name) {
+			return state.Name
+		}
+	}
+	return w.Initial()
+}
+
+// Completed returns the first state that means the work was finished, or
+// "" when there is none
+func (w *Workflow) Completed() string {
+	for _, state := range w.States {
+		if w.IsCompleted(state.Name) {
+			return state.Name
+		}
+	}
+	return ""
+}
+
+// Dropped returns the first state that means the work was abandoned, or ""
+// when there is none
+func (w *Workflow) Dropped() string {
+	for _, state := range w.States {
+		if w.IsDropped(state.Name) {
+			return state.Name
+		}
+	}
+	return ""
+}
+
+// IsGated reports whether entering a state requires a milestone's gates to
+// pass
+func (w *Workflow) IsGated(name string) bool {
+	state := w.State(name)
+	return state != nil && state.Gated
+}
+
+// Gated returns the first gated state, or "" when nothing is gated
+func (w *Workflow) Gated() string {
+	for _, state := range w.States {
+		if state.Gated {
+			return state.Name
+		}
+	}
+	return ""
+}
+
+// IsBlocked reports whether a state means the work cannot proceed
+func (w *Workflow) IsBlocked(name string) bool {
+	state := w.State(name)
+	return state != nil && state.Blocked
+}
+
+// Next returns the states reachable from name
+func (w *Workflow) Next(name string) []string {
+	state := w.State(name)
+	if state == nil || state.Transitions == nil {
+		var next []string
+		for _, candidate := range w.States {
+			if candidate.Name != name {
+				next = append(next, candidate.Name)
+			}
+		}
+		return next
+	}
+	return state.Transitions
+}
+
+// CheckTransition returns an error unless an item may move from one state to
+// another. Staying put is always allowed, and an unset or unknown current
+// state may move to any known state.
+func (w *Workflow) CheckTransition(from, to string) error {
+	if w.State(to) == nil {
+		return fmt.Errorf("unknown status %q (allowed: %s)", to, strings.Join(w.Names(), ", "))
+	}
+	if from == to || w.State(from) == nil {
+		return nil
+	}
+	for _, next := range w.Next(from) {
+		if next == to {
+			return nil
+		}
+	}
+
+	allowed := strings.Join(w.Next(from), ", ")
+	if allowed == "" {
+		allowed = "none"
+	}
+	return fmt.Errorf("cannot move from %s to %s (allowed from %s: %s)", from, to, from, allowed)
+}
+
+// Validate checks that a workflow is well formed
+func (w *Workflow) Validate() error {
+	if w == nil {
+		return fmt.Errorf("workflow is empty")
+	}
+	if len(w.States) == 0 {
+		return fmt.Errorf("workflow has no states")
+	}
+
+	seen := make(map[string]bool)
+	for _, state := range w.States {
+		if state.Name == "" {
+			return fmt.Errorf("workflow state without a name")
+		}
+		if seen[state.Name] {
+			return fmt.Errorf("workflow state %s is defined twice", state.Name)
+		}
+		seen[state.Name] = true
+		if state.Color != "" && !IsValidColor(state.Color) {
+			return fmt.Errorf("workflow state %s has invalid color %q (allowed: %s)", state.Name, state.Color, strings.Join(ValidColors, ", "))
+		}
+		if state.Dropped && !state.Terminal {
+			return fmt.Errorf("workflow state %s is dropped but not terminal", state.Name)
+		}
+	}
+
+	for _, state := range w.States {
+		for _, next := range state.Transitions {
+			if !seen[next] {
+				return fmt.Errorf("workflow state %s transitions to unknown state %s", state.Name, next)
+			}
+		}
+	}
+	return nil
+}
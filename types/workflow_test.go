@@ -0,0 +1,139 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWorkflowCheckTransition(t *testing.T) {
+	workflow := DefaultWorkflows[WorkflowStatus]
+	tests := []struct {
+		from, to string
+		wantErr  string
+	}{
+		{from: "planned", to: "in-progress"},
+		{from: "in-progress", to: "done"},
+		{from: "done", to: "in-progress"},
+		{from: "done", to: "done"},
+		{from: "", to: "blocked"},
+		{from: "legacy", to: "planned"},
+		{from: "done", to: "planned", wantErr: "cannot move from done to planned (allowed from done: in-progress)"},
+		{from: "blocked", to: "done", wantErr: "cannot move from blocked to done"},
+		{from: "planned", to: "shipped", wantErr: `unknown status "shipped"`},
+	}
+
+	for _, tt := range tests {
+		err := workflow.CheckTransition(tt.from, tt.to)
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("CheckTransition(%q, %q): %v", tt.from, tt.to, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("CheckTransition(%q, %q) error = %v, want %q", tt.from, tt.to, err, tt.wantErr)
+		}
+	}
+}
+
+func TestWorkflowRoles(t *testing.T) {
+	workflow := &Workflow{States: []WorkflowState{
+		{Name: "todo"},
+		{Name: "stuck", Blocked: true},
+		{Name: "shipped", Terminal: true, Gated: true},
+		{Name: "dropped", Terminal: true, Dropped: true},
+	}}
+
+	tests := []struct {
+		state                                     string
+		terminal, dropped, completed, gated, held bool
+	}{
+		{state: "todo"},
+		{state: "stuck", held: true},
+		{state: "shipped", terminal: true, completed: true, gated: true},
+		{state: "dropped", terminal: true, dropped: true},
+		{state: "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := workflow.IsTerminal(tt.state); got != tt.terminal {
+			t.Errorf("IsTerminal(%s) = %v", tt.state, got)
+		}
+		if got := workflow.IsDropped(tt.state); got != tt.dropped {
+			t.Errorf("IsDropped(%s) = %v", tt.state, got)
+		}
+		if got := workflow.IsCompleted(tt.state); got != tt.completed {
+			t.Errorf("IsCompleted(%s) = %v", tt.state, got)
+		}
+		if got := workflow.IsGated(tt.state); got != tt.gated {
+			t.Errorf("IsGated(%s) = %v", tt.state, got)
+		}
+		if got := workflow.IsBlocked(tt.state); got != tt.held {
+			t.Errorf("IsBlocked(%s) = %v", tt.state, got)
+		}
+	}
+
+	if got := workflow.Initial(); got != "todo" {
+		t.Errorf("Initial() = %s, want todo", got)
+	}
+	if got := workflow.Gated(); got != "shipped" {
+		t.Errorf("Gated() = %s, want shipped", got)
+	}
+	if got := workflow.Completed(); got != "shipped" {
+		t.Errorf("Completed() = %s, want shipped", got)
+	}
+	if got := workflow.Dropped(); got != "dropped" {
+		t.Errorf("Dropped() = %s, want dropped", got)
+	}
+	if got := workflow.Working(); got != "todo" {
+		t.Errorf("Working() = %s, want the initial state when none is working", got)
+	}
+	if got := DefaultWorkflows[WorkflowCycleStatus].Working(); got != "in-cycle" {
+		t.Errorf("cycle Working() = %s, want in-cycle", got)
+	}
+	if got := strings.Join(workflow.Next("todo"), ","); got != "stuck,shipped,dropped" {
+		t.Errorf("Next(todo) = %s, want every other state", got)
+	}
+}
+
+func TestWorkflowValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		workflow *Workflow
+		wantErr  string
+	}{
+		{name: "default", workflow: DefaultWorkflows[WorkflowStatus]},
+		{name: "nil", workflow: nil, wantErr: "workflow is empty"},
+		{name: "no states", workflow: &Workflow{}, wantErr: "no states"},
+		{name: "unnamed state", workflow: &Workflow{States: []WorkflowState{{Icon: "x"}}}, wantErr: "without a name"},
+		{
+			name:     "duplicate state",
+			workflow: &Workflow{States: []WorkflowState{{Name: "a"}, {Name: "a"}}},
+			wantErr:  "defined twice",
+		},
+		{
+			name:     "bad color",
+			workflow: &Workflow{States: []WorkflowState{{Name: "a", Color: "mauve"}}},
+			wantErr:  "invalid color",
+		},
+		{
+			name:     "dropped but not terminal",
+			workflow: &Workflow{States: []WorkflowState{{Name: "a", Dropped: true}}},
+			wantErr:  "dropped but not terminal",
+		},
+		{
+			name:     "unknown transition",
+			workflow: &Workflow{States: []WorkflowState{{Name: "a", Transitions: []string{"b"}}}},
+			wantErr:  "transitions to unknown state b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.workflow.Validate()
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("Validate error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}